@@ -1,10 +1,14 @@
 package main
 
 import (
+	"bufio"
 	"context"
 	"fmt"
+	"io"
 	"log"
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/olekukonko/tablewriter"
 	"github.com/pkg/errors"
@@ -16,6 +20,10 @@ import (
 
 var serverAddr string
 var verbose bool
+var rolling bool
+var maxUnavailable int32
+var signal string
+var daemonOpts process.DaemonOptions
 
 func main() {
 	log.SetFlags(log.Lshortfile | log.LstdFlags)
@@ -26,9 +34,17 @@ func main() {
 		Short:   "Start the gosupervisor daemon",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cfgPath := args[0]
-			return process.RunServer(cfgPath)
+			return process.RunServer(cfgPath, daemonOpts)
 		},
 	}
+	cmdDaemon.Flags().BoolVar(&daemonOpts.Daemonize, "daemonize", false, "run in the background, the daemon runs attached to the terminal by default")
+	cmdDaemon.Flags().StringVar(&daemonOpts.Pidfile, "pidfile", "", "write the daemon pid to this file")
+	cmdDaemon.Flags().StringVar(&daemonOpts.Logfile, "logfile", "", "write daemon logs to this file instead of stderr")
+	cmdDaemon.Flags().Int64Var(&daemonOpts.LogfileMaxBytes, "logfile-maxbytes", 50*1024*1024, "rotate the log file when it is larger")
+	cmdDaemon.Flags().IntVar(&daemonOpts.LogfileBackups, "logfile-backups", 10, "number of rotated log files to keep")
+	cmdDaemon.Flags().StringVar(&daemonOpts.LogLevel, "loglevel", "info", "debug, info, warn or error")
+	cmdDaemon.Flags().StringVar(&daemonOpts.Umask, "umask", "", "octal umask of the daemon and processes, e.g. 022")
+	cmdDaemon.Flags().StringVar(&daemonOpts.Chdir, "chdir", "", "change to this directory after reading the config")
 
 	var cmdStatus = &cobra.Command{
 		Use:   "status",
@@ -132,19 +148,132 @@ func main() {
 		Short: "Restart process",
 		Args:  cobra.MinimumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if rolling {
+				return runRollingRestart(args)
+			}
 			return runCmd(pb.CommandRequest_RESTART, args)
 		},
 	}
+	cmdRestart.Flags().BoolVar(&rolling, "rolling", false, "restart one batch after another, waiting for each to be RUNNING and healthy")
+	cmdRestart.Flags().Int32Var(&maxUnavailable, "max-unavailable", 1, "batch size of a rolling restart")
+
+	var cmdReload = &cobra.Command{
+		Use:   "reload NAME [NAME...]",
+		Short: "Send the reload signal to process",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCmd(pb.CommandRequest_RELOAD, args)
+		},
+	}
+
+	var cmdSignal = &cobra.Command{
+		Use:     "signal SIGNAL NAME [NAME...]",
+		Example: "signal USR1 web",
+		Short:   "Send signal to process",
+		Args:    cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			signal = args[0]
+			return runCmd(pb.CommandRequest_SIGNAL, args[1:])
+		},
+	}
+
+	var cmdEvents = &cobra.Command{
+		Use:   "events [NAME...]",
+		Short: "Print process state transitions and log rule matches as they happen",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			conn, err := grpc.Dial(serverAddr, grpc.WithInsecure())
+			if err != nil {
+				return errors.Wrapf(err, "did not connect daemon, addr:%v", serverAddr)
+			}
+			defer conn.Close()
+			c := pb.NewGoSupervisorClient(conn)
+			ctx := context.Background()
+			stream, err := c.Watch(ctx, &pb.WatchRequest{ProcessName: args})
+			if err != nil {
+				return errors.Wrap(err, "call Watch")
+			}
+			for {
+				ev, err := stream.Recv()
+				if err == io.EOF {
+					return nil
+				}
+				if err != nil {
+					return errors.Wrap(err, "recv event")
+				}
+				t := time.Unix(0, ev.Timestamp*int64(time.Millisecond))
+				if ev.LogRule != "" {
+					fmt.Printf("%s %s log rule %s matched pid:%d %s\n",
+						t.Format("2006-01-02 15:04:05.000"), ev.ProcessName, ev.LogRule, ev.Pid, ev.LogLine)
+					continue
+				}
+				fmt.Printf("%s %s %v->%v pid:%d exit_code:%d %s\n",
+					t.Format("2006-01-02 15:04:05.000"), ev.ProcessName, ev.FromStatus, ev.ToStatus, ev.Pid, ev.ExitCode, ev.ProcessDesc)
+			}
+		},
+	}
+
+	var cmdFg = &cobra.Command{
+		Use:   "fg NAME",
+		Short: "Attach to process, print its output and send typed lines to its stdin, Ctrl-C or Ctrl-D detaches",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runFg(args[0])
+		},
+	}
+
+	var cmdResize = &cobra.Command{
+		Use:     "resize NAME ROWS COLS",
+		Example: "resize console 50 200",
+		Short:   "Set the window size of the pty of process",
+		Args:    cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			rows, err := strconv.ParseUint(args[1], 10, 16)
+			if err != nil {
+				return errors.Errorf("bad rows %q", args[1])
+			}
+			cols, err := strconv.ParseUint(args[2], 10, 16)
+			if err != nil {
+				return errors.Errorf("bad cols %q", args[2])
+			}
+			conn, err := grpc.Dial(serverAddr, grpc.WithInsecure())
+			if err != nil {
+				return errors.Wrapf(err, "did not connect daemon, addr:%v", serverAddr)
+			}
+			defer conn.Close()
+			c := pb.NewGoSupervisorClient(conn)
+			_, err = c.Resize(context.Background(), &pb.ResizeRequest{ProcessName: args[0], Rows: uint32(rows), Cols: uint32(cols)})
+			return errors.Wrap(err, "call Resize")
+		},
+	}
+
+	var cmdExec = &cobra.Command{
+		Use:     "exec NAME -- COMMAND [ARG...]",
+		Example: "exec web -- env",
+		Short:   "Run a command in the directory and environment of process with allow_exec and exit with its exit code",
+		Args:    cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			code, err := runExec(args[0], args[1:])
+			if err != nil {
+				return err
+			}
+			os.Exit(code)
+			return nil
+		},
+	}
 
 	var rootCmd = &cobra.Command{Use: "gosupervisor"}
 	rootCmd.AddCommand(cmdDaemon)
 	rootCmd.AddCommand(cmdStatus)
 	rootCmd.AddCommand(cmdPing)
 	rootCmd.AddCommand(cmdKill, cmdStop, cmdStart, cmdRestart)
+	rootCmd.AddCommand(cmdReload, cmdSignal)
+	rootCmd.AddCommand(cmdEvents)
+	rootCmd.AddCommand(cmdFg, cmdResize, cmdExec)
 	rootCmd.Flags().StringVarP(&serverAddr, "server_addr", "s", "127.0.0.1:7766", "daemon listen addr to connect")
 	err := rootCmd.Execute()
 	if err != nil {
 		log.Println("err", err)
+		os.Exit(1)
 	}
 }
 
@@ -158,7 +287,7 @@ func runCmd(cmd pb.CommandRequest_Command, args []string) error {
 		c := pb.NewGoSupervisorClient(conn)
 		ctx := context.Background()
 		fmt.Printf("exec %v %v\n", cmd.String(), name)
-		_, err = c.Command(ctx, &pb.CommandRequest{Command: cmd, ProcessName: name})
+		_, err = c.Command(ctx, &pb.CommandRequest{Command: cmd, ProcessName: name, Signal: signal})
 		if err != nil {
 			return errors.Wrapf(err, "exec %v", cmd.String())
 		}
@@ -166,3 +295,102 @@ func runCmd(cmd pb.CommandRequest_Command, args []string) error {
 	}
 	return nil
 }
+
+func runRollingRestart(args []string) error {
+	conn, err := grpc.Dial(serverAddr, grpc.WithInsecure())
+	if err != nil {
+		return errors.Wrapf(err, "did not connect daemon, addr:%v", serverAddr)
+	}
+	defer conn.Close()
+	c := pb.NewGoSupervisorClient(conn)
+	ctx := context.Background()
+	fmt.Printf("exec rolling RESTART %v max-unavailable:%d\n", args, maxUnavailable)
+	_, err = c.Command(ctx, &pb.CommandRequest{
+		Command:        pb.CommandRequest_RESTART,
+		Rolling:        true,
+		ProcessNames:   args,
+		MaxUnavailable: maxUnavailable,
+	})
+	if err != nil {
+		return errors.Wrap(err, "exec rolling RESTART")
+	}
+	fmt.Printf("exec rolling RESTART %v success\n", args)
+	return nil
+}
+
+// runFg attaches to a process until stdin is closed, the process keeps running after that
+func runFg(name string) error {
+	conn, err := grpc.Dial(serverAddr, grpc.WithInsecure())
+	if err != nil {
+		return errors.Wrapf(err, "did not connect daemon, addr:%v", serverAddr)
+	}
+	defer conn.Close()
+	c := pb.NewGoSupervisorClient(conn)
+	stream, err := c.Attach(context.Background())
+	if err != nil {
+		return errors.Wrap(err, "call Attach")
+	}
+	if err := stream.Send(&pb.AttachRequest{ProcessName: name}); err != nil {
+		return errors.Wrap(err, "send attach request")
+	}
+	go func() {
+		reader := bufio.NewReader(os.Stdin)
+		for {
+			line, err := reader.ReadBytes('\n')
+			if len(line) > 0 {
+				if stream.Send(&pb.AttachRequest{Input: line}) != nil {
+					return
+				}
+			}
+			if err != nil {
+				stream.CloseSend()
+				return
+			}
+		}
+	}()
+	for {
+		reply, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return errors.Wrap(err, "recv output")
+		}
+		if reply.Stream == pb.AttachReply_STDERR {
+			os.Stderr.Write(reply.Data)
+		} else {
+			os.Stdout.Write(reply.Data)
+		}
+	}
+}
+
+// runExec runs args through the daemon and returns the exit code
+func runExec(name string, args []string) (int, error) {
+	conn, err := grpc.Dial(serverAddr, grpc.WithInsecure())
+	if err != nil {
+		return 0, errors.Wrapf(err, "did not connect daemon, addr:%v", serverAddr)
+	}
+	defer conn.Close()
+	c := pb.NewGoSupervisorClient(conn)
+	stream, err := c.Exec(context.Background(), &pb.ExecRequest{ProcessName: name, Args: args})
+	if err != nil {
+		return 0, errors.Wrap(err, "call Exec")
+	}
+	for {
+		reply, err := stream.Recv()
+		if err == io.EOF {
+			return 0, errors.New("exec ended without exit code")
+		}
+		if err != nil {
+			return 0, errors.Wrap(err, "recv output")
+		}
+		if reply.Exited {
+			return int(reply.ExitCode), nil
+		}
+		if reply.Stream == pb.AttachReply_STDERR {
+			os.Stderr.Write(reply.Data)
+		} else {
+			os.Stdout.Write(reply.Data)
+		}
+	}
+}
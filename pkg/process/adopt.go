@@ -0,0 +1,148 @@
+package process
+
+import (
+	"os"
+	"os/exec"
+	"time"
+
+	pb "github.com/wangkechun/gosupervisor/pkg/proto"
+)
+
+const adoptPollInterval = time.Second
+
+// procIdentity is what identifies a process across pid reuse
+type procIdentity struct {
+	startTime uint64
+	cmdline   []string
+}
+
+func readProcIdentity(pid int) (procIdentity, error) {
+	stat, err := readProcStat(pid)
+	if err != nil {
+		return procIdentity{}, err
+	}
+	cmdline, err := readProcCmdline(pid)
+	if err != nil {
+		return procIdentity{}, err
+	}
+	return procIdentity{startTime: stat.startTime, cmdline: cmdline}, nil
+}
+
+func (id procIdentity) match(startTime uint64, cmdline []string) bool {
+	if id.startTime != startTime || len(id.cmdline) != len(cmdline) {
+		return false
+	}
+	for i := range cmdline {
+		if id.cmdline[i] != cmdline[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// procAlive reports whether pid is still the process started at startTime
+func procAlive(pid int, startTime uint64) bool {
+	stat, err := readProcStat(pid)
+	return err == nil && stat.startTime == startTime && stat.state != "Z"
+}
+
+// saveIdentity records the running process in st so the next daemon can adopt it,
+// must be called with lock held
+func (p *processInstances) saveIdentity(st *pb.SavedProcess) {
+	if p.cmd == nil || p.cmd.Process == nil {
+		return
+	}
+	select {
+	case <-p.exited:
+		return
+	default:
+	}
+	pid := p.cmd.Process.Pid
+	id, err := readProcIdentity(pid)
+	if err != nil {
+		return
+	}
+	st.Pid = int32(pid)
+	st.StartTime = id.startTime
+	st.Cmdline = id.cmdline
+}
+
+// adopt takes over a process left running by a previous daemon, it returns false
+// if the saved pid is gone or now belongs to another process.
+// The output is read from the named pipes in the output dir again, it is not captured
+// when the process was not started with them.
+func (p *processInstances) adopt(st *pb.SavedProcess) bool {
+	switch st.Status {
+	case pb.ProcessStatus_STARTING, pb.ProcessStatus_RUNNING, pb.ProcessStatus_UNHEALTHY:
+	default:
+		return false
+	}
+	if st.Pid == 0 {
+		return false
+	}
+	if p.spec.Pty || p.listener != nil {
+		// the terminal and the eventlistener protocol are gone with the previous daemon
+		logWarn("process on a pty or eventlistener is not adopted", "process_name", p.spec.ProcessName, "pid", st.Pid)
+		return false
+	}
+	pid := int(st.Pid)
+	id, err := readProcIdentity(pid)
+	if err != nil || !id.match(st.StartTime, st.Cmdline) {
+		logWarn("saved process is gone, not adopted", "process_name", p.spec.ProcessName, "pid", pid)
+		return false
+	}
+	proc, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	var fifos *outputFifos
+	if p.outputDir != "" {
+		fifos, err = openOutputFifos(p.outputDir, p.spec.ProcessName)
+		if err != nil {
+			logWarn("output of adopted process is not captured", "process_name", p.spec.ProcessName, "pid", pid, "err", err)
+		}
+	}
+	if fifos != nil {
+		fifos.copyOutput(p.outputWriters())
+	}
+	exited := make(chan struct{})
+	p.lock.Lock()
+	p.cmd = &exec.Cmd{Path: st.Cmdline[0], Args: st.Cmdline, Process: proc}
+	p.exited = exited
+	p.status.LastStartedTime = st.LastStartedTime
+	p.setStatus(pb.ProcessStatus_RUNNING, "adopted")
+	p.lock.Unlock()
+	logInfo("adopt process", "process_name", p.spec.ProcessName, "pid", pid)
+
+	go p.watchAdopted(pid, st.StartTime, exited, fifos)
+	if p.spec.Healthcheck != nil {
+		go p.runHealthCheck(exited)
+	}
+	return true
+}
+
+// watchAdopted polls an adopted process, it is not our child so it can not be waited for
+func (p *processInstances) watchAdopted(pid int, startTime uint64, exited chan struct{}, fifos *outputFifos) {
+	ticker := time.NewTicker(adoptPollInterval)
+	defer ticker.Stop()
+	for range ticker.C {
+		if procAlive(pid, startTime) {
+			continue
+		}
+		if fifos != nil {
+			fifos.wait()
+		}
+		p.flushOutput()
+		// stop waits for exited with lock held
+		close(exited)
+		p.lock.Lock()
+		if p.status.Status != pb.ProcessStatus_STOPPING && p.status.Status != pb.ProcessStatus_STOPPED {
+			// the exit code of a process which is not our child is unknown
+			p.lastExitCode = -1
+			p.setStatus(pb.ProcessStatus_EXITED, "adopted process exited")
+		}
+		p.lock.Unlock()
+		logInfo("adopted process exit", "process_name", p.spec.ProcessName, "pid", pid)
+		return
+	}
+}
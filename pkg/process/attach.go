@@ -0,0 +1,78 @@
+package process
+
+import (
+	"io"
+	"sync"
+
+	"github.com/pkg/errors"
+	pb "github.com/wangkechun/gosupervisor/pkg/proto"
+)
+
+const outputSubscriberBuffer = 256
+
+// outputHub fans out the output of a process to attached clients.
+// Like eventHub, a slow client never blocks the process, its output is dropped instead.
+type outputHub struct {
+	lock        sync.Mutex
+	subscribers map[chan *pb.AttachReply]struct{}
+}
+
+func newOutputHub() *outputHub {
+	return &outputHub{subscribers: make(map[chan *pb.AttachReply]struct{})}
+}
+
+func (h *outputHub) subscribe() chan *pb.AttachReply {
+	ch := make(chan *pb.AttachReply, outputSubscriberBuffer)
+	h.lock.Lock()
+	h.subscribers[ch] = struct{}{}
+	h.lock.Unlock()
+	return ch
+}
+
+func (h *outputHub) unsubscribe(ch chan *pb.AttachReply) {
+	h.lock.Lock()
+	delete(h.subscribers, ch)
+	h.lock.Unlock()
+}
+
+// writer returns a writer publishing to the subscribers as stream
+func (h *outputHub) writer(stream pb.AttachReply_Stream) io.Writer {
+	return &outputWriter{hub: h, stream: stream}
+}
+
+type outputWriter struct {
+	hub    *outputHub
+	stream pb.AttachReply_Stream
+}
+
+func (w *outputWriter) Write(data []byte) (int, error) {
+	w.hub.lock.Lock()
+	defer w.hub.lock.Unlock()
+	if len(w.hub.subscribers) == 0 {
+		return len(data), nil
+	}
+	reply := &pb.AttachReply{Stream: w.stream, Data: append([]byte(nil), data...)}
+	for ch := range w.hub.subscribers {
+		select {
+		case ch <- reply:
+		default:
+		}
+	}
+	return len(data), nil
+}
+
+// writeStdin writes input to the stdin pipe of the current run
+func (p *processInstances) writeStdin(input []byte) error {
+	if !p.spec.Stdin {
+		return errors.Errorf("stdin of process %v is not enabled", p.spec.ProcessName)
+	}
+	p.lock.RLock()
+	stdin := p.stdin
+	p.lock.RUnlock()
+	if stdin == nil {
+		return errors.Errorf("process %v not started", p.spec.ProcessName)
+	}
+	// the pipe is closed once the process exited, so this never blocks a dead process
+	_, err := stdin.Write(input)
+	return errors.Wrap(err, "write stdin")
+}
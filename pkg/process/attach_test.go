@@ -0,0 +1,162 @@
+package process
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	pb "github.com/wangkechun/gosupervisor/pkg/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// dialTestServer serves the grpc api of s on a local port
+func dialTestServer(t *testing.T, s *serverInstance) (pb.GoSupervisorClient, func()) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	server := grpc.NewServer()
+	pb.RegisterGoSupervisorServer(server, s)
+	go server.Serve(lis)
+	conn, err := grpc.Dial(lis.Addr().String(), grpc.WithInsecure())
+	assert.Nil(t, err)
+	return pb.NewGoSupervisorClient(conn), func() {
+		conn.Close()
+		server.Stop()
+	}
+}
+
+func TestAttach(t *testing.T) {
+	a := assert.New(t)
+	s := newServerInstance(&pb.ConfigFile{Version: ServiceVersion, Process: []*pb.ProcessSpec{
+		{ProcessName: "repl", Command: `sh -c 'while read l; do echo "got $l"; done'`, Stdin: true, Startsecs: 0.1},
+		{ProcessName: "web", Command: "sleep 60", Startsecs: 0.1},
+	}})
+	a.Nil(s.initLoad())
+	for _, p := range s.process {
+		a.Nil(p.start(startByManual))
+		defer p.kill()
+		a.Nil(p.waitHealthy())
+	}
+
+	c, closeClient := dialTestServer(t, s)
+	defer closeClient()
+
+	stream, err := c.Attach(context.Background())
+	a.Nil(err)
+	a.Nil(stream.Send(&pb.AttachRequest{ProcessName: "repl", Input: []byte("hello\n")}))
+	reply, err := stream.Recv()
+	a.Nil(err)
+	a.Equal(pb.AttachReply_STDOUT, reply.Stream)
+	a.Equal("got hello\n", string(reply.Data))
+
+	// supervisorctl fg writes with sendProcessStdin
+	srv := httptest.NewServer(s.httpHandler())
+	defer srv.Close()
+	r, fault := callXMLRPC(t, srv.URL, `<methodCall><methodName>supervisor.sendProcessStdin</methodName><params>
+<param><value>repl</value></param><param><value>world
+</value></param></params></methodCall>`)
+	a.Nil(fault)
+	a.Equal(true, r)
+	reply, err = stream.Recv()
+	a.Nil(err)
+	a.Equal("got world\n", string(reply.Data))
+	_, fault = callXMLRPC(t, srv.URL, `<methodCall><methodName>supervisor.sendProcessStdin</methodName><params>
+<param><value>web</value></param><param><value>x</value></param></params></methodCall>`)
+	a.Equal(int64(faultNoFile), fault["faultCode"])
+
+	// detaching keeps the process running
+	a.Nil(stream.CloseSend())
+	_, err = stream.Recv()
+	a.Equal(io.EOF, err)
+	a.Equal(pb.ProcessStatus_RUNNING, s.process["repl"].readStatus().Status.Status)
+
+	stream, err = c.Attach(context.Background())
+	a.Nil(err)
+	a.Nil(stream.Send(&pb.AttachRequest{ProcessName: "web", Input: []byte("x\n")}))
+	_, err = stream.Recv()
+	a.Equal(codes.FailedPrecondition, status.Code(err))
+
+	stream, err = c.Attach(context.Background())
+	a.Nil(err)
+	a.Nil(stream.Send(&pb.AttachRequest{ProcessName: "none"}))
+	_, err = stream.Recv()
+	a.Equal(codes.NotFound, status.Code(err))
+}
+
+func TestExec(t *testing.T) {
+	a := assert.New(t)
+	dir, err := ioutil.TempDir("", "gosupervisor")
+	a.Nil(err)
+	defer os.RemoveAll(dir)
+	s := newServerInstance(&pb.ConfigFile{Version: ServiceVersion, Process: []*pb.ProcessSpec{
+		{ProcessName: "web", Command: "sleep 60", Directory: dir, Environment: []string{"APP_ENV=prod"}, AllowExec: true},
+		{ProcessName: "db", Command: "sleep 60"},
+	}})
+	a.Nil(s.initLoad())
+	c, closeClient := dialTestServer(t, s)
+	defer closeClient()
+
+	stream, err := c.Exec(context.Background(), &pb.ExecRequest{ProcessName: "web", Args: []string{"sh", "-c", "pwd; echo $APP_ENV >&2; exit 3"}})
+	a.Nil(err)
+	output := map[pb.AttachReply_Stream]string{}
+	var last *pb.ExecReply
+	for {
+		reply, err := stream.Recv()
+		if err != nil {
+			a.Equal(io.EOF, err)
+			break
+		}
+		output[reply.Stream] += string(reply.Data)
+		last = reply
+	}
+	realDir, err := filepath.EvalSymlinks(dir)
+	a.Nil(err)
+	a.Equal(realDir+"\n", output[pb.AttachReply_STDOUT])
+	a.Equal("prod\n", output[pb.AttachReply_STDERR])
+	a.True(last.Exited)
+	a.Equal(int32(3), last.ExitCode)
+	// the process is not touched
+	a.Equal(pb.ProcessStatus_INIT, s.process["web"].readStatus().Status.Status)
+
+	// going away kills the children of the command too
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, err = c.Exec(ctx, &pb.ExecRequest{ProcessName: "web", Args: []string{"sh", "-c", "sleep 60 & echo $!; wait"}})
+	a.Nil(err)
+	reply, err := stream.Recv()
+	a.Nil(err)
+	pid, err := strconv.Atoi(strings.TrimSpace(string(reply.Data)))
+	a.Nil(err)
+	cancel()
+	alive := true
+	for i := 0; i < 30 && alive; i++ {
+		time.Sleep(time.Millisecond * 100)
+		// the killed sleep may stay a zombie of an init which does not reap
+		buf, err := ioutil.ReadFile(fmt.Sprintf("/proc/%d/stat", pid))
+		alive = err == nil && !strings.Contains(string(buf), ") Z ")
+	}
+	a.False(alive)
+
+	stream, err = c.Exec(context.Background(), &pb.ExecRequest{ProcessName: "web", Args: []string{"/nonexistent"}})
+	a.Nil(err)
+	_, err = stream.Recv()
+	a.Equal(codes.FailedPrecondition, status.Code(err))
+	stream, err = c.Exec(context.Background(), &pb.ExecRequest{ProcessName: "db", Args: []string{"true"}})
+	a.Nil(err)
+	_, err = stream.Recv()
+	a.Equal(codes.PermissionDenied, status.Code(err))
+	stream, err = c.Exec(context.Background(), &pb.ExecRequest{ProcessName: "none", Args: []string{"true"}})
+	a.Nil(err)
+	_, err = stream.Recv()
+	a.Equal(codes.NotFound, status.Code(err))
+}
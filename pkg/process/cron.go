@@ -0,0 +1,263 @@
+package process
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	pb "github.com/wangkechun/gosupervisor/pkg/proto"
+)
+
+// cronMaxLookahead bounds the search for the next run of schedules like "0 0 30 2 *"
+const cronMaxLookahead = 5 * 366 * 24 * time.Hour
+
+var cronShortcuts = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// cronSchedule is a parsed "minute hour day-of-month month day-of-week" expression,
+// each field is a bit set of the allowed values.
+type cronSchedule struct {
+	minute, hour, dom, month, dow uint64
+	domStar, dowStar              bool
+}
+
+type cronField struct {
+	min, max int
+}
+
+var cronFields = []cronField{{0, 59}, {0, 23}, {1, 31}, {1, 12}, {0, 7}}
+
+func parseCron(expr string) (*cronSchedule, error) {
+	expr = strings.TrimSpace(expr)
+	if v, ok := cronShortcuts[expr]; ok {
+		expr = v
+	}
+	fields := strings.Fields(expr)
+	if len(fields) != len(cronFields) {
+		return nil, errors.Errorf("cron expression %q needs 5 fields", expr)
+	}
+	sets := make([]uint64, len(fields))
+	for i, f := range fields {
+		set, err := parseCronField(f, cronFields[i])
+		if err != nil {
+			return nil, errors.Wrapf(err, "cron expression %q", expr)
+		}
+		sets[i] = set
+	}
+	// both 0 and 7 are sunday
+	if sets[4]&(1<<7) != 0 {
+		sets[4] |= 1
+	}
+	return &cronSchedule{
+		minute: sets[0], hour: sets[1], dom: sets[2], month: sets[3], dow: sets[4],
+		domStar: fields[2] == "*", dowStar: fields[4] == "*",
+	}, nil
+}
+
+// parseCronField parses a comma separated list of *, N, N-M with an optional /STEP
+func parseCronField(s string, f cronField) (uint64, error) {
+	var set uint64
+	for _, part := range strings.Split(s, ",") {
+		step := 1
+		if i := strings.IndexByte(part, '/'); i >= 0 {
+			n, err := strconv.Atoi(part[i+1:])
+			if err != nil || n <= 0 {
+				return 0, errors.Errorf("bad step %q", part)
+			}
+			step = n
+			part = part[:i]
+		}
+		lo, hi := f.min, f.max
+		switch {
+		case part == "*":
+		case strings.Contains(part, "-"):
+			r := strings.SplitN(part, "-", 2)
+			var err1, err2 error
+			lo, err1 = strconv.Atoi(r[0])
+			hi, err2 = strconv.Atoi(r[1])
+			if err1 != nil || err2 != nil {
+				return 0, errors.Errorf("bad range %q", part)
+			}
+		default:
+			n, err := strconv.Atoi(part)
+			if err != nil {
+				return 0, errors.Errorf("bad value %q", part)
+			}
+			lo, hi = n, n
+			if step > 1 {
+				hi = f.max
+			}
+		}
+		if lo < f.min || hi > f.max || lo > hi {
+			return 0, errors.Errorf("%q out of range %d-%d", part, f.min, f.max)
+		}
+		for v := lo; v <= hi; v += step {
+			set |= 1 << uint(v)
+		}
+	}
+	return set, nil
+}
+
+func (c *cronSchedule) dayMatches(t time.Time) bool {
+	dom := c.dom&(1<<uint(t.Day())) != 0
+	dow := c.dow&(1<<uint(t.Weekday())) != 0
+	// like cron, a restricted day-of-month or day-of-week matches either
+	if c.domStar || c.dowStar {
+		return dom && dow
+	}
+	return dom || dow
+}
+
+// next returns the first time after t matching the schedule, zero if there is none
+func (c *cronSchedule) next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	end := t.Add(cronMaxLookahead)
+	for t.Before(end) {
+		if c.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !c.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if c.hour&(1<<uint(t.Hour())) == 0 {
+			// Truncate works on absolute time, which is off the local hour in zones like +05:30
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+		if c.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// alive reports whether the current run has not exited yet
+func (p *processInstances) alive() bool {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	if p.cmd == nil {
+		return false
+	}
+	select {
+	case <-p.exited:
+		return false
+	default:
+		return true
+	}
+}
+
+type scheduleResult int
+
+const (
+	scheduleStarted scheduleResult = iota
+	scheduleQueued
+	scheduleSkipped
+)
+
+// runScheduled starts a scheduled run unless the previous run is still running
+func (p *processInstances) runScheduled() scheduleResult {
+	name := p.spec.ProcessName
+	if p.alive() {
+		switch p.spec.Overlap {
+		case pb.ProcessSpec_SKIP:
+			logInfo("schedule skip, previous run still running", "process_name", name)
+			return scheduleSkipped
+		case pb.ProcessSpec_QUEUE:
+			logInfo("schedule queued, previous run still running", "process_name", name)
+			return scheduleQueued
+		case pb.ProcessSpec_KILL_PREVIOUS:
+			logInfo("schedule stop previous run", "process_name", name)
+			p.lock.Lock()
+			err := p.stopLocked()
+			p.lock.Unlock()
+			if err != nil {
+				logWarn("schedule stop previous run failed", "process_name", name, "err", err)
+			}
+		}
+	}
+	logInfo("schedule start process", "process_name", name)
+	if err := p.start(startByRestart); err != nil {
+		logWarn("schedule start process failed", "process_name", name, "err", err)
+	}
+	return scheduleStarted
+}
+
+// scheduleTick runs a scheduled tick and returns whether a run is queued behind the current one,
+// a run started by the tick takes the place of the queued one
+func (p *processInstances) scheduleTick(queued bool) bool {
+	switch p.runScheduled() {
+	case scheduleStarted:
+		return false
+	case scheduleQueued:
+		return true
+	}
+	return queued
+}
+
+func (p *processInstances) runSchedule(ctx context.Context, sched *cronSchedule) {
+	queued := false
+	for {
+		next := sched.next(time.Now())
+		if next.IsZero() {
+			logWarn("schedule never runs", "process_name", p.spec.ProcessName, "schedule", p.spec.Schedule)
+			return
+		}
+		p.lock.Lock()
+		p.status.NextRunTime = int32(next.Unix())
+		var exited chan struct{}
+		if queued {
+			exited = p.exited
+		}
+		p.lock.Unlock()
+
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-exited:
+			timer.Stop()
+			if p.startGate.closed() {
+				return
+			}
+			queued = false
+			p.runScheduled()
+			continue
+		case <-timer.C:
+		}
+		if p.startGate.closed() {
+			return
+		}
+		queued = p.scheduleTick(queued)
+	}
+}
+
+// initRunScheduler starts scheduled processes according to their cron expression
+func (s *serverInstance) initRunScheduler(ctx context.Context) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	for _, p := range s.process {
+		if p.spec.Schedule == "" {
+			continue
+		}
+		sched, err := parseCron(p.spec.Schedule)
+		if err != nil {
+			logWarn("parse schedule failed", "process_name", p.spec.ProcessName, "err", err)
+			continue
+		}
+		go p.runSchedule(ctx, sched)
+	}
+}
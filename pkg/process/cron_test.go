@@ -0,0 +1,101 @@
+package process
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	pb "github.com/wangkechun/gosupervisor/pkg/proto"
+)
+
+func TestParseCron(t *testing.T) {
+	a := assert.New(t)
+	for _, v := range []string{"* * * *", "60 * * * *", "*/0 * * * *", "5-1 * * * *", "a * * * *", "* * 0 * *"} {
+		_, err := parseCron(v)
+		a.NotNil(err, v)
+	}
+	c, err := parseCron("1,2,10-12 */6 * * 7")
+	a.Nil(err)
+	a.Equal(uint64(1<<1|1<<2|1<<10|1<<11|1<<12), c.minute)
+	a.Equal(uint64(1<<0|1<<6|1<<12|1<<18), c.hour)
+	a.Equal(uint64(1<<0|1<<7), c.dow)
+}
+
+func TestCronNext(t *testing.T) {
+	a := assert.New(t)
+	at := func(s string) time.Time {
+		v, err := time.ParseInLocation("2006-01-02 15:04", s, time.Local)
+		a.Nil(err)
+		return v
+	}
+	cases := []struct {
+		expr, from, next string
+	}{
+		{"* * * * *", "2019-03-01 10:00", "2019-03-01 10:01"},
+		{"*/15 * * * *", "2019-03-01 10:07", "2019-03-01 10:15"},
+		{"30 2 * * *", "2019-03-01 10:07", "2019-03-02 02:30"},
+		{"@hourly", "2019-03-01 10:07", "2019-03-01 11:00"},
+		{"@monthly", "2019-12-15 10:07", "2020-01-01 00:00"},
+		{"0 0 29 2 *", "2019-03-01 00:00", "2020-02-29 00:00"},
+		// 2019-03-04 is a monday, either day-of-month or day-of-week matches
+		{"0 9 10 * 1", "2019-03-01 00:00", "2019-03-04 09:00"},
+		{"0 9 * * 0", "2019-03-01 00:00", "2019-03-03 09:00"},
+	}
+	for _, c := range cases {
+		s, err := parseCron(c.expr)
+		a.Nil(err, c.expr)
+		a.Equal(at(c.next), s.next(at(c.from)), c.expr)
+	}
+	s, err := parseCron("0 0 30 2 *")
+	a.Nil(err)
+	a.True(s.next(at("2019-03-01 00:00")).IsZero())
+
+	// hours start at :30 of UTC hours in a +05:30 zone
+	kolkata := time.FixedZone("IST", 5*3600+1800)
+	s, err = parseCron("0 11 * * *")
+	a.Nil(err)
+	from := time.Date(2019, 3, 1, 10, 45, 0, 0, kolkata)
+	a.Equal(time.Date(2019, 3, 1, 11, 0, 0, 0, kolkata), s.next(from))
+}
+
+func TestScheduledExit(t *testing.T) {
+	a := assert.New(t)
+	p, err := newProcessInstances(&pb.ProcessSpec{ProcessName: "job", Command: "sh -c 'exit 3'", Schedule: "@daily"})
+	a.Nil(err)
+	a.Nil(p.start(startByAuto))
+	a.Nil(p.cmd)
+
+	a.Equal(scheduleStarted, p.runScheduled())
+	<-p.exited
+	time.Sleep(time.Millisecond * 100)
+	st := p.readStatus().Status
+	a.Equal(pb.ProcessStatus_EXITED, st.Status)
+	a.Equal("exit status 3", st.LastRunResult)
+
+	p.spec.Command = "sleep 60"
+	p.args = []string{"sleep", "60"}
+	p.spec.Overlap = pb.ProcessSpec_QUEUE
+	a.Equal(scheduleStarted, p.runScheduled())
+	a.Equal(scheduleQueued, p.runScheduled())
+	p.spec.Overlap = pb.ProcessSpec_KILL_PREVIOUS
+	pid := p.readStatus().Status.Pid
+	a.Equal(scheduleStarted, p.runScheduled())
+	a.NotEqual(pid, p.readStatus().Status.Pid)
+	a.Equal(pb.SavedProcess_UNSET, p.saveState().Desired)
+	p.kill()
+}
+
+func TestScheduleTickQueue(t *testing.T) {
+	a := assert.New(t)
+	p, err := newProcessInstances(&pb.ProcessSpec{ProcessName: "job", Command: "sleep 0.2", Schedule: "@daily", Overlap: pb.ProcessSpec_QUEUE})
+	a.Nil(err)
+	a.False(p.scheduleTick(false))
+	// the tick overlaps the running run and is queued
+	a.True(p.scheduleTick(false))
+	<-p.exited
+	time.Sleep(time.Millisecond * 100)
+	// a normal tick starts the run, nothing is left queued to start again on its exit
+	a.False(p.scheduleTick(true))
+	a.True(p.alive())
+	p.kill()
+}
@@ -0,0 +1,145 @@
+package process
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/pkg/errors"
+)
+
+// daemonChildEnv marks the background process started by --daemonize
+const daemonChildEnv = "GOSUPERVISOR_DAEMONIZED"
+
+// daemonReadyFd is the pipe the background process reports readiness on, the first of ExtraFiles
+const daemonReadyFd = 3
+
+// daemonReadyMsg is written to the ready pipe once the daemon serves, anything else is an error
+const daemonReadyMsg = "ok"
+
+// DaemonOptions controls how the daemon runs as a system service
+type DaemonOptions struct {
+	// Daemonize detaches the daemon from the terminal and runs it in the background
+	Daemonize       bool
+	Pidfile         string
+	Logfile         string
+	LogfileMaxBytes int64
+	LogfileBackups  int
+	LogLevel        string
+	// Umask is an octal string like 022, the umask is kept when empty
+	Umask string
+	Chdir string
+}
+
+// writePidfile creates the pidfile, a pidfile left by a dead daemon is replaced
+func writePidfile(path string) error {
+	for i := 0; i < 2; i++ {
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+		if err == nil {
+			_, err = fmt.Fprintf(f, "%d\n", os.Getpid())
+			f.Close()
+			return errors.Wrap(err, "write pidfile")
+		}
+		if !os.IsExist(err) {
+			return errors.Wrap(err, "create pidfile")
+		}
+		buf, err := ioutil.ReadFile(path)
+		if err != nil {
+			return errors.Wrap(err, "read pidfile")
+		}
+		pid, err := strconv.Atoi(strings.TrimSpace(string(buf)))
+		if err == nil && pid != os.Getpid() && pidAlive(pid) {
+			return errors.Errorf("daemon already running, pid:%v pidfile:%v", pid, path)
+		}
+		logWarn("remove stale pidfile", "pidfile", path, "pid", strings.TrimSpace(string(buf)))
+		if err := os.Remove(path); err != nil {
+			return errors.Wrap(err, "remove stale pidfile")
+		}
+	}
+	return errors.Errorf("create pidfile %v failed", path)
+}
+
+// applyDaemonOptions sets up logging, umask and working directory of the daemon
+func applyDaemonOptions(opts DaemonOptions) error {
+	if opts.Umask != "" {
+		mask, err := strconv.ParseUint(opts.Umask, 8, 32)
+		if err != nil {
+			return errors.Errorf("bad umask %q", opts.Umask)
+		}
+		if err := setUmask(int(mask)); err != nil {
+			return err
+		}
+	}
+	if opts.LogLevel != "" {
+		level, err := parseLogLevel(opts.LogLevel)
+		if err != nil {
+			return err
+		}
+		currentLogLevel = level
+	}
+	if opts.Logfile != "" {
+		path, err := filepath.Abs(opts.Logfile)
+		if err != nil {
+			return errors.Wrap(err, "log file path")
+		}
+		f, err := openRotatingFile(path, opts.LogfileMaxBytes, opts.LogfileBackups)
+		if err != nil {
+			return err
+		}
+		log.SetOutput(f)
+	}
+	if opts.Chdir != "" {
+		if err := os.Chdir(opts.Chdir); err != nil {
+			return errors.Wrap(err, "chdir")
+		}
+	}
+	return nil
+}
+
+// notifyDaemonReady tells the starting process the daemon is serving, or the error it failed with
+func notifyDaemonReady(ready *os.File, err error) {
+	if ready == nil {
+		return
+	}
+	msg := daemonReadyMsg
+	if err != nil {
+		msg = strings.Replace(err.Error(), "\n", " ", -1)
+	}
+	fmt.Fprintln(ready, msg)
+	ready.Close()
+}
+
+// waitDaemonReady waits for the background process to report on r
+func waitDaemonReady(r io.Reader) error {
+	line, err := bufio.NewReader(r).ReadString('\n')
+	line = strings.TrimSuffix(line, "\n")
+	if err != nil && line == "" {
+		return errors.New("background daemon exited before it was ready")
+	}
+	if line != daemonReadyMsg {
+		return errors.New(line)
+	}
+	return nil
+}
+
+// exitOnSignal calls exit on SIGTERM and SIGINT. The processes keep running for the next daemon
+// to adopt when there is a state file, they are stopped first otherwise.
+func (s *serverInstance) exitOnSignal(exit func(code int)) {
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGTERM, syscall.SIGINT)
+	sig := <-sigs
+	logInfo("daemon got signal, exit", "signal", sig)
+	if s.config.StateFile == "" || !outputFifoSupported {
+		// nothing adopts them, and their output would go nowhere
+		s.stopAll()
+	}
+	exit(0)
+}
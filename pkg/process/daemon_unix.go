@@ -0,0 +1,67 @@
+//go:build !windows
+// +build !windows
+
+package process
+
+import (
+	"os"
+	"os/exec"
+	"syscall"
+
+	"github.com/pkg/errors"
+)
+
+// daemonize starts the daemon again in a new session in the background, it returns the pid of it
+// once the background process reports it is serving, or the error it failed with.
+// It returns 0 and the pipe to report readiness on in the background process.
+func daemonize() (int, *os.File, error) {
+	if os.Getenv(daemonChildEnv) != "" {
+		// keep processes started by the daemon from taking the variable as their own
+		os.Unsetenv(daemonChildEnv)
+		syscall.CloseOnExec(daemonReadyFd)
+		return 0, os.NewFile(daemonReadyFd, "daemon ready"), nil
+	}
+	exe, err := os.Executable()
+	if err != nil {
+		return 0, nil, errors.Wrap(err, "find executable")
+	}
+	devnull, err := os.OpenFile(os.DevNull, os.O_RDWR, 0)
+	if err != nil {
+		return 0, nil, errors.Wrap(err, "open /dev/null")
+	}
+	defer devnull.Close()
+	r, w, err := os.Pipe()
+	if err != nil {
+		return 0, nil, errors.Wrap(err, "create ready pipe")
+	}
+	defer r.Close()
+	cmd := exec.Command(exe, os.Args[1:]...)
+	cmd.Env = append(os.Environ(), daemonChildEnv+"=1")
+	cmd.Stdin = devnull
+	cmd.Stdout = devnull
+	cmd.Stderr = devnull
+	cmd.ExtraFiles = []*os.File{w}
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true}
+	err = cmd.Start()
+	w.Close()
+	if err != nil {
+		return 0, nil, errors.Wrap(err, "start background daemon")
+	}
+	err = waitDaemonReady(r)
+	if err != nil {
+		cmd.Wait()
+		return 0, nil, err
+	}
+	return cmd.Process.Pid, nil, nil
+}
+
+// pidAlive reports whether a process with pid exists
+func pidAlive(pid int) bool {
+	err := syscall.Kill(pid, 0)
+	return err == nil || err == syscall.EPERM
+}
+
+func setUmask(mask int) error {
+	syscall.Umask(mask)
+	return nil
+}
@@ -0,0 +1,28 @@
+//go:build windows
+// +build windows
+
+package process
+
+import (
+	"os"
+
+	"github.com/pkg/errors"
+)
+
+func daemonize() (int, *os.File, error) {
+	return 0, nil, errors.New("daemonize not supported on windows")
+}
+
+// pidAlive reports whether a process with pid exists
+func pidAlive(pid int) bool {
+	p, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	p.Release()
+	return true
+}
+
+func setUmask(mask int) error {
+	return errors.New("umask not supported on windows")
+}
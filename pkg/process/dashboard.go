@@ -0,0 +1,186 @@
+package process
+
+import (
+	"net/http"
+)
+
+func httpDashboard(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Write([]byte(dashboardHTML))
+}
+
+const dashboardHTML = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>gosupervisor</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; margin: 0; background: #f5f6f8; color: #222; }
+header { background: #24292e; color: #fff; padding: 10px 20px; display: flex; justify-content: space-between; align-items: center; }
+header h1 { font-size: 18px; margin: 0; }
+#conn { font-size: 12px; }
+main { padding: 20px; }
+table { width: 100%; border-collapse: collapse; background: #fff; }
+th, td { text-align: left; padding: 6px 10px; border-bottom: 1px solid #e1e4e8; font-size: 13px; }
+tr.selected { background: #f1f8ff; }
+tbody tr { cursor: pointer; }
+.status { font-weight: bold; }
+.RUNNING, .COMPLETED { color: #28a745; }
+.STARTING, .STOPPING { color: #dbab09; }
+.BACKOFF, .FATAL, .UNHEALTHY { color: #cb2431; }
+.STOPPED, .EXITED, .INIT { color: #6a737d; }
+button { font-size: 12px; margin-right: 4px; cursor: pointer; }
+#detail { display: none; margin-top: 20px; background: #fff; padding: 10px 20px; }
+#graphs { display: flex; }
+#graphs div { margin-right: 20px; font-size: 12px; }
+canvas { border: 1px solid #e1e4e8; }
+pre { background: #1e1e1e; color: #ddd; height: 360px; overflow: auto; padding: 10px; font-size: 12px; }
+</style>
+</head>
+<body>
+<header><h1>gosupervisor</h1><span id="conn">connecting</span></header>
+<main>
+<table>
+<thead><tr><th>ProcessName</th><th>Pid</th><th>Status</th><th>ProcessDesc</th><th>Memory</th><th>CPU</th><th>Desc</th><th></th></tr></thead>
+<tbody id="processes"></tbody>
+</table>
+<div id="detail">
+<h3 id="detail-name"></h3>
+<div id="graphs">
+<div>Memory (KB)<br><canvas id="mem" width="400" height="100"></canvas></div>
+<div>CPU (%)<br><canvas id="cpu" width="400" height="100"></canvas></div>
+</div>
+<p>
+<label><input type="radio" name="stream" value="stdout" checked> stdout</label>
+<label><input type="radio" name="stream" value="stderr"> stderr</label>
+</p>
+<pre id="log"></pre>
+</div>
+</main>
+<script>
+var historySize = 120; // samples kept by the resource graphs
+var selected = null;
+var samples = {};
+
+function el(tag, text) {
+  var e = document.createElement(tag);
+  if (text !== undefined) e.textContent = text;
+  return e;
+}
+
+function command(name, cmd) {
+  fetch("/api/processes/" + encodeURIComponent(name) + "/" + cmd, {method: "POST", headers: {"X-Requested-With": "XMLHttpRequest"}})
+    .then(function(resp) { return resp.json(); })
+    .then(function(r) { if (r.error) alert(cmd + " " + name + ": " + r.error); refresh(); });
+}
+
+function record(p) {
+  var h = samples[p.spec.processName] || (samples[p.spec.processName] = {mem: [], cpu: []});
+  h.mem.push(p.status.memoryUsage);
+  h.cpu.push(p.status.cpuPercent);
+  if (h.mem.length > historySize) { h.mem.shift(); h.cpu.shift(); }
+}
+
+function render(list) {
+  var body = document.getElementById("processes");
+  body.innerHTML = "";
+  (list.process || []).forEach(function(p) {
+    var name = p.spec.processName;
+    var tr = el("tr");
+    if (name === selected) tr.className = "selected";
+    tr.appendChild(el("td", name));
+    tr.appendChild(el("td", p.status.pid || ""));
+    var st = el("td", p.status.status);
+    st.className = "status " + p.status.status;
+    tr.appendChild(st);
+    tr.appendChild(el("td", p.status.processDesc));
+    tr.appendChild(el("td", p.status.memoryUsage ? p.status.memoryUsage + " KB" : ""));
+    tr.appendChild(el("td", p.status.pid ? p.status.cpuPercent.toFixed(1) + "%" : ""));
+    tr.appendChild(el("td", p.spec.desc));
+    var ops = el("td");
+    ["start", "stop", "restart"].forEach(function(cmd) {
+      var b = el("button", cmd);
+      b.onclick = function(e) { e.stopPropagation(); command(name, cmd); };
+      ops.appendChild(b);
+    });
+    tr.appendChild(ops);
+    tr.onclick = function() { select(name); };
+    body.appendChild(tr);
+  });
+}
+
+function refresh() {
+  fetch("/api/processes").then(function(resp) { return resp.json(); }).then(function(list) {
+    (list.process || []).forEach(record);
+    render(list);
+    drawGraphs();
+  });
+}
+
+function drawGraph(id, data) {
+  var c = document.getElementById(id), ctx = c.getContext("2d");
+  ctx.clearRect(0, 0, c.width, c.height);
+  if (!data || data.length < 2) return;
+  var max = Math.max.apply(null, data) || 1;
+  ctx.strokeStyle = "#0366d6";
+  ctx.beginPath();
+  data.forEach(function(v, i) {
+    var x = i * c.width / (historySize - 1), y = c.height - v / max * (c.height - 10) - 5;
+    if (i === 0) ctx.moveTo(x, y); else ctx.lineTo(x, y);
+  });
+  ctx.stroke();
+  ctx.fillStyle = "#586069";
+  ctx.fillText("max " + max.toFixed(1), 4, 12);
+}
+
+function drawGraphs() {
+  if (!selected) return;
+  var h = samples[selected] || {};
+  drawGraph("mem", h.mem);
+  drawGraph("cpu", h.cpu);
+}
+
+function loadLog() {
+  if (!selected) return;
+  var stream = document.querySelector("input[name=stream]:checked").value;
+  fetch("/api/processes/" + encodeURIComponent(selected) + "/log?stream=" + stream)
+    .then(function(resp) { return resp.text(); })
+    .then(function(text) {
+      var pre = document.getElementById("log");
+      var bottom = pre.scrollTop + pre.clientHeight >= pre.scrollHeight - 5;
+      pre.textContent = text;
+      if (bottom) pre.scrollTop = pre.scrollHeight;
+    });
+}
+
+function select(name) {
+  selected = name;
+  document.getElementById("detail").style.display = "block";
+  document.getElementById("detail-name").textContent = name;
+  document.getElementById("log").textContent = "";
+  refresh();
+  loadLog();
+}
+
+document.querySelectorAll("input[name=stream]").forEach(function(e) { e.onchange = loadLog; });
+
+function connect() {
+  var es = new EventSource("/api/events");
+  var conn = document.getElementById("conn");
+  es.onopen = function() { conn.textContent = "live"; };
+  es.onmessage = function() { refresh(); };
+  es.onerror = function() { conn.textContent = "reconnecting"; };
+}
+
+connect();
+refresh();
+setInterval(refresh, 2000);
+setInterval(loadLog, 2000);
+</script>
+</body>
+</html>
+`
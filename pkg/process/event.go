@@ -0,0 +1,70 @@
+package process
+
+import (
+	"sync"
+	"time"
+
+	pb "github.com/wangkechun/gosupervisor/pkg/proto"
+)
+
+const eventBufferSize = 256
+
+// eventHub fans out process state transitions to every subscriber.
+// A slow subscriber never blocks the process being watched, its events are dropped instead.
+type eventHub struct {
+	lock        sync.Mutex
+	subscribers map[chan *pb.ProcessEvent]struct{}
+}
+
+func newEventHub() *eventHub {
+	return &eventHub{subscribers: make(map[chan *pb.ProcessEvent]struct{})}
+}
+
+func (h *eventHub) subscribe() chan *pb.ProcessEvent {
+	ch := make(chan *pb.ProcessEvent, eventBufferSize)
+	h.lock.Lock()
+	h.subscribers[ch] = struct{}{}
+	h.lock.Unlock()
+	return ch
+}
+
+func (h *eventHub) unsubscribe(ch chan *pb.ProcessEvent) {
+	h.lock.Lock()
+	delete(h.subscribers, ch)
+	h.lock.Unlock()
+}
+
+func (h *eventHub) publish(ev *pb.ProcessEvent) {
+	if h == nil {
+		return
+	}
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	for ch := range h.subscribers {
+		select {
+		case ch <- ev:
+		default:
+			logWarn("event subscriber too slow, drop event", "process_name", ev.ProcessName, "status", ev.ToStatus)
+		}
+	}
+}
+
+func newProcessEvent(name string, from, to pb.ProcessStatus_Status) *pb.ProcessEvent {
+	return &pb.ProcessEvent{
+		ProcessName: name,
+		FromStatus:  from,
+		ToStatus:    to,
+		Timestamp:   time.Now().UnixNano() / int64(time.Millisecond),
+	}
+}
+
+func watchFilter(names []string) func(ev *pb.ProcessEvent) bool {
+	if len(names) == 0 {
+		return func(ev *pb.ProcessEvent) bool { return true }
+	}
+	m := make(map[string]bool, len(names))
+	for _, name := range names {
+		m[name] = true
+	}
+	return func(ev *pb.ProcessEvent) bool { return m[ev.ProcessName] }
+}
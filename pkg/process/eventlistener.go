@@ -0,0 +1,318 @@
+package process
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+	pb "github.com/wangkechun/gosupervisor/pkg/proto"
+)
+
+const defaultListenerBufferSize = 10
+const tickCheckInterval = time.Second
+
+var tickPeriods = []int64{5, 60, 3600}
+
+// listenerSerial is the global serial of events sent to eventlisteners
+var listenerSerial int64
+
+// listenerEvent is an event in supervisord eventlistener format
+type listenerEvent struct {
+	serial  int64
+	name    string
+	payload string
+}
+
+func newListenerEvent(name, payload string) *listenerEvent {
+	return &listenerEvent{serial: atomic.AddInt64(&listenerSerial, 1), name: name, payload: payload}
+}
+
+func newProcessStateListenerEvent(ev *pb.ProcessEvent, spec *pb.ProcessSpec) *listenerEvent {
+	payload := fmt.Sprintf("processname:%s groupname:%s from_state:%s", ev.ProcessName, ev.ProcessName, supervisordState(ev.FromStatus))
+	switch ev.ToStatus {
+	case pb.ProcessStatus_EXITED:
+		expected := 0
+		if isExpectedExitCode(spec, ev.ExitCode) {
+			expected = 1
+		}
+		payload += fmt.Sprintf(" expected:%d pid:%d", expected, ev.Pid)
+	case pb.ProcessStatus_RUNNING, pb.ProcessStatus_UNHEALTHY, pb.ProcessStatus_STOPPING, pb.ProcessStatus_STOPPED:
+		payload += fmt.Sprintf(" pid:%d", ev.Pid)
+	}
+	return newListenerEvent("PROCESS_STATE_"+ev.ToStatus.String(), payload)
+}
+
+// supervisordState names st like supervisord, a process not started yet is STOPPED there
+func supervisordState(st pb.ProcessStatus_Status) string {
+	if st == pb.ProcessStatus_INIT {
+		return pb.ProcessStatus_STOPPED.String()
+	}
+	return st.String()
+}
+
+// newLogMatchListenerEvent is like supervisord PROCESS_LOG events, the matched line follows the header
+func newLogMatchListenerEvent(ev *pb.ProcessEvent) *listenerEvent {
+	payload := fmt.Sprintf("processname:%s groupname:%s pid:%d rule:%s\n%s",
+		ev.ProcessName, ev.ProcessName, ev.Pid, ev.LogRule, ev.LogLine)
+	return newListenerEvent("PROCESS_LOG_MATCH", payload)
+}
+
+func isExpectedExitCode(spec *pb.ProcessSpec, code int32) bool {
+	if len(spec.Exitcodes) == 0 {
+		return code == 0
+	}
+	for _, v := range spec.Exitcodes {
+		if v == code {
+			return true
+		}
+	}
+	return false
+}
+
+// eventListener implements the supervisord eventlistener protocol over the stdin and stdout of a process.
+// The event buffer outlives the process, so events are kept while the listener restarts.
+type eventListener struct {
+	name          string
+	subscriptions []string
+	queue         chan *listenerEvent
+	poolSerial    int64
+	lock          sync.Mutex
+	pending       *listenerEvent // event rejected by the listener, it is sent again first
+}
+
+func newEventListener(spec *pb.ProcessSpec) *eventListener {
+	size := int(spec.BufferSize)
+	if size <= 0 {
+		size = defaultListenerBufferSize
+	}
+	return &eventListener{name: spec.ProcessName, subscriptions: spec.Events, queue: make(chan *listenerEvent, size)}
+}
+
+func (l *eventListener) subscribed(name string) bool {
+	for _, v := range l.subscriptions {
+		if v == "EVENT" || v == name || strings.HasPrefix(name, v+"_") {
+			return true
+		}
+	}
+	return false
+}
+
+func (l *eventListener) enqueue(ev *listenerEvent) {
+	for {
+		select {
+		case l.queue <- ev:
+			return
+		default:
+		}
+		select {
+		case old := <-l.queue:
+			logWarn("eventlistener buffer overflow, drop event", "process_name", l.name, "event", old.name, "serial", old.serial)
+		default:
+		}
+	}
+}
+
+func (l *eventListener) setPending(ev *listenerEvent) {
+	l.lock.Lock()
+	l.pending = ev
+	l.lock.Unlock()
+}
+
+func (l *eventListener) takePending() *listenerEvent {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	ev := l.pending
+	l.pending = nil
+	return ev
+}
+
+// attach replaces the stdin and stdout of cmd with pipes speaking the eventlistener protocol,
+// the returned function must be called after cmd.Start with whether the process started.
+func (l *eventListener) attach(cmd *exec.Cmd) (func(started bool), error) {
+	stdinR, stdinW, err := os.Pipe()
+	if err != nil {
+		return nil, errors.Wrap(err, "create stdin pipe")
+	}
+	stdoutR, stdoutW, err := os.Pipe()
+	if err != nil {
+		stdinR.Close()
+		stdinW.Close()
+		return nil, errors.Wrap(err, "create stdout pipe")
+	}
+	cmd.Stdin = stdinR
+	cmd.Stdout = stdoutW
+	return func(started bool) {
+		stdinR.Close()
+		stdoutW.Close()
+		if !started {
+			stdinW.Close()
+			stdoutR.Close()
+			return
+		}
+		go func() {
+			defer stdinW.Close()
+			defer stdoutR.Close()
+			err := l.serve(stdoutR, stdinW)
+			if err != nil {
+				logWarn("eventlistener failed", "process_name", l.name, "err", err)
+			}
+		}()
+	}, nil
+}
+
+type listenerMessage struct {
+	ready  bool
+	result string
+}
+
+// readListenerMessages parses the output of the listener until it fails or done is closed
+func readListenerMessages(r io.Reader, msgs chan<- listenerMessage, done <-chan struct{}) {
+	defer close(msgs)
+	send := func(msg listenerMessage) bool {
+		select {
+		case msgs <- msg:
+			return true
+		case <-done:
+			return false
+		}
+	}
+	br := bufio.NewReader(r)
+	for {
+		line, err := br.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "READY":
+			if !send(listenerMessage{ready: true}) {
+				return
+			}
+		case strings.HasPrefix(line, "RESULT "):
+			n, err := strconv.Atoi(strings.TrimPrefix(line, "RESULT "))
+			if err != nil || n < 0 {
+				logWarn("eventlistener bad result header", "line", line)
+				continue
+			}
+			body := make([]byte, n)
+			if _, err := io.ReadFull(br, body); err != nil {
+				return
+			}
+			if !send(listenerMessage{result: string(body)}) {
+				return
+			}
+		default:
+			logWarn("eventlistener unexpected output", "line", line)
+		}
+	}
+}
+
+// serve runs the READY/RESULT handshake until the listener closes its stdout.
+func (l *eventListener) serve(r io.Reader, w io.Writer) error {
+	msgs := make(chan listenerMessage)
+	// the reader stops once serve returned, the caller closes r to end a blocked read
+	done := make(chan struct{})
+	defer close(done)
+	go readListenerMessages(r, msgs, done)
+	for {
+		msg, ok := <-msgs
+		if !ok {
+			return nil
+		}
+		if !msg.ready {
+			logWarn("eventlistener send result while not processing event", "process_name", l.name)
+			continue
+		}
+		ev := l.takePending()
+		for ev == nil {
+			select {
+			case ev = <-l.queue:
+			case msg, ok := <-msgs:
+				if !ok {
+					return nil
+				}
+				logWarn("eventlistener unexpected message while ready", "process_name", l.name, "result", msg.result)
+			}
+		}
+		poolSerial := atomic.AddInt64(&l.poolSerial, 1)
+		header := fmt.Sprintf("ver:3.0 server:supervisor serial:%d pool:%s poolserial:%d eventname:%s len:%d\n",
+			ev.serial, l.name, poolSerial, ev.name, len(ev.payload))
+		if _, err := io.WriteString(w, header+ev.payload); err != nil {
+			l.setPending(ev)
+			return errors.Wrap(err, "write event")
+		}
+		msg, ok = <-msgs
+		if !ok {
+			l.setPending(ev)
+			return nil
+		}
+		if msg.result != "OK" {
+			logWarn("eventlistener rejected event", "process_name", l.name, "event", ev.name, "result", msg.result)
+			l.setPending(ev)
+		}
+	}
+}
+
+// initRunEventListener feeds process state transitions and ticks to every eventlistener,
+// ch is subscribed before any process starts so no transition is missed
+func (s *serverInstance) initRunEventListener(ctx context.Context, ch chan *pb.ProcessEvent) {
+	defer s.events.unsubscribe(ch)
+	s.lock.RLock()
+	listeners := make([]*eventListener, 0)
+	for _, v := range s.process {
+		if v.listener != nil {
+			listeners = append(listeners, v.listener)
+		}
+	}
+	s.lock.RUnlock()
+	if len(listeners) == 0 {
+		return
+	}
+	dispatch := func(ev *listenerEvent, from string) {
+		for _, l := range listeners {
+			// do not notify a listener about itself, it would loop on its own restarts
+			if l.name != from && l.subscribed(ev.name) {
+				l.enqueue(ev)
+			}
+		}
+	}
+
+	ticker := time.NewTicker(tickCheckInterval)
+	defer ticker.Stop()
+	lastTick := make(map[int64]int64)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case ev := <-ch:
+			s.lock.RLock()
+			p, ok := s.process[ev.ProcessName]
+			s.lock.RUnlock()
+			if !ok {
+				continue
+			}
+			if ev.LogRule != "" {
+				dispatch(newLogMatchListenerEvent(ev), ev.ProcessName)
+				continue
+			}
+			dispatch(newProcessStateListenerEvent(ev, p.spec), ev.ProcessName)
+		case now := <-ticker.C:
+			for _, period := range tickPeriods {
+				n := now.Unix() / period
+				if last, ok := lastTick[period]; ok && last != n {
+					dispatch(newListenerEvent(fmt.Sprintf("TICK_%d", period), fmt.Sprintf("when:%d", n*period)), "")
+				}
+				lastTick[period] = n
+			}
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package process
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	pb "github.com/wangkechun/gosupervisor/pkg/proto"
+)
+
+// readEvent reads one event written by the supervisor, returning header tokens and payload
+func readEvent(t *testing.T, r *bufio.Reader) (map[string]string, string) {
+	line, err := r.ReadString('\n')
+	assert.Nil(t, err)
+	header := make(map[string]string)
+	for _, token := range strings.Fields(line) {
+		kv := strings.SplitN(token, ":", 2)
+		header[kv[0]] = kv[1]
+	}
+	n, err := strconv.Atoi(header["len"])
+	assert.Nil(t, err)
+	payload := make([]byte, n)
+	_, err = io.ReadFull(r, payload)
+	assert.Nil(t, err)
+	return header, string(payload)
+}
+
+func TestEventListenerProtocol(t *testing.T) {
+	a := assert.New(t)
+	l := newEventListener(&pb.ProcessSpec{ProcessName: "crashmail", Events: []string{"PROCESS_STATE"}, BufferSize: 2})
+	a.True(l.subscribed("PROCESS_STATE_FATAL"))
+	a.False(l.subscribed("TICK_5"))
+
+	spec := &pb.ProcessSpec{ProcessName: "web", Exitcodes: []int32{0, 2}}
+	ev := &pb.ProcessEvent{ProcessName: "web", FromStatus: pb.ProcessStatus_RUNNING, ToStatus: pb.ProcessStatus_EXITED, Pid: 42, ExitCode: 2}
+	l.enqueue(newProcessStateListenerEvent(ev, spec))
+	ev = &pb.ProcessEvent{ProcessName: "web", FromStatus: pb.ProcessStatus_BACKOFF, ToStatus: pb.ProcessStatus_FATAL}
+	l.enqueue(newProcessStateListenerEvent(ev, spec))
+
+	stdinR, stdinW := io.Pipe()
+	stdoutR, stdoutW := io.Pipe()
+	done := make(chan error)
+	go func() {
+		done <- l.serve(stdoutR, stdinW)
+	}()
+	r := bufio.NewReader(stdinR)
+
+	io.WriteString(stdoutW, "READY\n")
+	header, payload := readEvent(t, r)
+	a.Equal("PROCESS_STATE_EXITED", header["eventname"])
+	a.Equal("crashmail", header["pool"])
+	a.Equal("1", header["poolserial"])
+	a.Equal("processname:web groupname:web from_state:RUNNING expected:1 pid:42", payload)
+
+	// a rejected event is sent again
+	io.WriteString(stdoutW, "RESULT 4\nFAILREADY\n")
+	header, _ = readEvent(t, r)
+	a.Equal("PROCESS_STATE_EXITED", header["eventname"])
+	a.Equal("2", header["poolserial"])
+
+	io.WriteString(stdoutW, "RESULT 2\nOKREADY\n")
+	header, payload = readEvent(t, r)
+	a.Equal("PROCESS_STATE_FATAL", header["eventname"])
+	a.Equal("processname:web groupname:web from_state:BACKOFF", payload)
+
+	io.WriteString(stdoutW, "RESULT 2\nOK")
+	stdoutW.Close()
+	a.Nil(<-done)
+	// supervisord has no INIT state
+	ev = &pb.ProcessEvent{ProcessName: "web", FromStatus: pb.ProcessStatus_INIT, ToStatus: pb.ProcessStatus_STARTING}
+	a.Equal("processname:web groupname:web from_state:STOPPED", newProcessStateListenerEvent(ev, spec).payload)
+}
+
+func TestEventListenerReaderStops(t *testing.T) {
+	stdoutR, stdoutW := io.Pipe()
+	msgs := make(chan listenerMessage)
+	done := make(chan struct{})
+	finished := make(chan struct{})
+	go func() {
+		readListenerMessages(stdoutR, msgs, done)
+		close(finished)
+	}()
+	// serve gave up, nobody receives the message any more
+	close(done)
+	io.WriteString(stdoutW, "READY\n")
+	select {
+	case <-finished:
+	case <-time.After(time.Second):
+		t.Fatal("reader blocked after serve returned")
+	}
+}
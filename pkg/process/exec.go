@@ -0,0 +1,70 @@
+package process
+
+import (
+	"sync"
+
+	"github.com/pkg/errors"
+	pb "github.com/wangkechun/gosupervisor/pkg/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// execWriter sends the output of an Exec command, the stdout and stderr writers share lock
+// as a grpc stream must not be sent to concurrently
+type execWriter struct {
+	lock   *sync.Mutex
+	stream pb.GoSupervisor_ExecServer
+	output pb.AttachReply_Stream
+}
+
+func (w *execWriter) Write(data []byte) (int, error) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if err := w.stream.Send(&pb.ExecReply{Stream: w.output, Data: data}); err != nil {
+		return 0, errors.Wrap(err, "send output")
+	}
+	return len(data), nil
+}
+
+// Exec runs a command like the process would be started, the status of the process is not changed.
+// The command and its children are killed when the client goes away.
+func (s *serverInstance) Exec(req *pb.ExecRequest, stream pb.GoSupervisor_ExecServer) error {
+	s.lock.RLock()
+	p, ok := s.process[req.ProcessName]
+	s.lock.RUnlock()
+	if !ok {
+		return status.Errorf(codes.NotFound, "process %v not found", req.ProcessName)
+	}
+	if !p.spec.AllowExec {
+		return status.Errorf(codes.PermissionDenied, "exec in process %v is not allowed, set allow_exec", req.ProcessName)
+	}
+	if len(req.Args) == 0 {
+		return status.Error(codes.InvalidArgument, "empty command")
+	}
+	logInfo("exec in process environment", "process_name", req.ProcessName, "args", req.Args)
+	cmd := p.command(req.Args)
+	var lock sync.Mutex
+	cmd.Stdout = &execWriter{lock: &lock, stream: stream, output: pb.AttachReply_STDOUT}
+	cmd.Stderr = &execWriter{lock: &lock, stream: stream, output: pb.AttachReply_STDERR}
+	// a shell may leave children behind, they are killed with the command
+	setProcessGroup(cmd)
+	if err := startChild(cmd); err != nil {
+		return status.Errorf(codes.FailedPrecondition, "start command: %v", err)
+	}
+	done := make(chan struct{})
+	go func() {
+		select {
+		case <-stream.Context().Done():
+			killProcessGroup(cmd)
+		case <-done:
+		}
+	}()
+	// an exit code other than 0 is not an error of Exec
+	waitChild(cmd)
+	close(done)
+	code := exitCode(cmd.ProcessState)
+	logInfo("exec exited", "process_name", req.ProcessName, "pid", cmd.Process.Pid, "exit_code", code)
+	lock.Lock()
+	defer lock.Unlock()
+	return stream.Send(&pb.ExecReply{Exited: true, ExitCode: int32(code)})
+}
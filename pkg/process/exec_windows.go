@@ -0,0 +1,13 @@
+//go:build windows
+// +build windows
+
+package process
+
+import "os/exec"
+
+func setProcessGroup(cmd *exec.Cmd) {}
+
+// killProcessGroup kills cmd only, windows has no process groups to signal
+func killProcessGroup(cmd *exec.Cmd) {
+	cmd.Process.Kill()
+}
@@ -0,0 +1,103 @@
+package process
+
+import (
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// outputFifos carries the output of a run through named pipes in the output dir of the daemon,
+// the process keeps writing to them while no daemon runs, until the next daemon adopts it
+type outputFifos struct {
+	stdout *os.File // read ends of the daemon
+	stderr *os.File
+	done   chan struct{} // closed once the output is copied
+}
+
+func outputFifoPaths(dir, name string) (string, string) {
+	return filepath.Join(dir, name+".stdout"), filepath.Join(dir, name+".stderr")
+}
+
+// newOutputFifos sets up cmd to write its output to new named pipes,
+// the caller closes the returned files after cmd started
+func newOutputFifos(cmd *exec.Cmd, dir, name string) (*outputFifos, []*os.File, error) {
+	stdoutPath, stderrPath := outputFifoPaths(dir, name)
+	stdoutW, stdoutR, err := makeOutputFifo(stdoutPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	stderrW, stderrR, err := makeOutputFifo(stderrPath)
+	if err != nil {
+		stdoutW.Close()
+		stdoutR.Close()
+		return nil, nil, err
+	}
+	cmd.Stdout = stdoutW
+	cmd.Stderr = stderrW
+	f := &outputFifos{stdout: stdoutR, stderr: stderrR, done: make(chan struct{})}
+	return f, []*os.File{stdoutW, stderrW}, nil
+}
+
+// openOutputFifos opens the named pipes a process started by a previous daemon writes to
+func openOutputFifos(dir, name string) (*outputFifos, error) {
+	stdoutPath, stderrPath := outputFifoPaths(dir, name)
+	for _, path := range []string{stdoutPath, stderrPath} {
+		fi, err := os.Stat(path)
+		if err != nil {
+			return nil, errors.Wrap(err, "stat fifo")
+		}
+		if fi.Mode()&os.ModeNamedPipe == 0 {
+			return nil, errors.Errorf("%v is not a fifo", path)
+		}
+	}
+	stdoutR, err := openOutputFifo(stdoutPath)
+	if err != nil {
+		return nil, err
+	}
+	stderrR, err := openOutputFifo(stderrPath)
+	if err != nil {
+		stdoutR.Close()
+		return nil, err
+	}
+	return &outputFifos{stdout: stdoutR, stderr: stderrR, done: make(chan struct{})}, nil
+}
+
+// copyOutput copies the output until the process and its children closed the pipes
+func (f *outputFifos) copyOutput(stdout, stderr io.Writer) {
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		io.Copy(stdout, f.stdout)
+	}()
+	go func() {
+		defer wg.Done()
+		io.Copy(stderr, f.stderr)
+	}()
+	go func() {
+		wg.Wait()
+		close(f.done)
+	}()
+}
+
+// wait reads the remaining output after the process exited and closes the pipes,
+// a child of the process still writing is not hurt as it holds a read end too
+func (f *outputFifos) wait() {
+	select {
+	case <-f.done:
+	case <-time.After(outputDrainTimeout):
+	}
+	f.close()
+	<-f.done
+}
+
+// close closes the read ends of the daemon
+func (f *outputFifos) close() {
+	f.stdout.Close()
+	f.stderr.Close()
+}
@@ -0,0 +1,44 @@
+//go:build linux
+// +build linux
+
+package process
+
+import (
+	"os"
+	"syscall"
+
+	"github.com/pkg/errors"
+)
+
+// outputFifoSupported reports whether the output of processes can go through named pipes,
+// only processes on linux are adopted
+const outputFifoSupported = true
+
+// makeOutputFifo creates a named pipe at path, it returns the end for the process and the read end
+// of the daemon. The process end is opened for reading too, so writing to the pipe while no daemon
+// reads it blocks once the pipe is full instead of failing with EPIPE.
+func makeOutputFifo(path string) (w, r *os.File, err error) {
+	os.Remove(path)
+	if err := syscall.Mkfifo(path, 0600); err != nil {
+		return nil, nil, errors.Wrap(err, "mkfifo")
+	}
+	w, err = os.OpenFile(path, os.O_RDWR, 0)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "open fifo")
+	}
+	r, err = openOutputFifo(path)
+	if err != nil {
+		w.Close()
+		return nil, nil, err
+	}
+	return w, r, nil
+}
+
+// openOutputFifo opens the read end of a named pipe without waiting for a writer
+func openOutputFifo(path string) (*os.File, error) {
+	r, err := os.OpenFile(path, os.O_RDONLY|syscall.O_NONBLOCK, 0)
+	if err != nil {
+		return nil, errors.Wrap(err, "open fifo")
+	}
+	return r, nil
+}
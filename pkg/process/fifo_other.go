@@ -0,0 +1,20 @@
+//go:build !linux
+// +build !linux
+
+package process
+
+import (
+	"os"
+
+	"github.com/pkg/errors"
+)
+
+const outputFifoSupported = false
+
+func makeOutputFifo(path string) (w, r *os.File, err error) {
+	return nil, nil, errors.New("output fifo is only supported on linux")
+}
+
+func openOutputFifo(path string) (*os.File, error) {
+	return nil, errors.New("output fifo is only supported on linux")
+}
@@ -0,0 +1,147 @@
+package process
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"time"
+
+	"github.com/pkg/errors"
+	pb "github.com/wangkechun/gosupervisor/pkg/proto"
+)
+
+const defaultHealthCheckInterval = time.Second * 10
+const defaultHealthCheckTimeout = time.Second * 3
+const defaultHealthCheckFailureThreshold = 3
+
+// probe checks one of an http url, a tcp address, a shell command or a file
+type probe struct {
+	httpGet    string
+	tcpConnect string
+	exec       string
+	file       string
+	dir        string
+	env        []string
+}
+
+func (pr *probe) empty() bool {
+	return pr.httpGet == "" && pr.tcpConnect == "" && pr.exec == "" && pr.file == ""
+}
+
+func (pr *probe) check(ctx context.Context) error {
+	switch {
+	case pr.httpGet != "":
+		req, err := http.NewRequest(http.MethodGet, pr.httpGet, nil)
+		if err != nil {
+			return errors.Wrap(err, "new request")
+		}
+		resp, err := http.DefaultClient.Do(req.WithContext(ctx))
+		if err != nil {
+			return errors.Wrap(err, "http get")
+		}
+		resp.Body.Close()
+		if resp.StatusCode < 200 || resp.StatusCode >= 400 {
+			return errors.Errorf("http get status %v", resp.Status)
+		}
+		return nil
+	case pr.tcpConnect != "":
+		var d net.Dialer
+		conn, err := d.DialContext(ctx, "tcp", pr.tcpConnect)
+		if err != nil {
+			return errors.Wrap(err, "tcp connect")
+		}
+		conn.Close()
+		return nil
+	case pr.exec != "":
+		cmd := exec.CommandContext(ctx, "sh", "-c", pr.exec)
+		cmd.Dir = pr.dir
+		cmd.Env = pr.env
+		var out bytes.Buffer
+		cmd.Stdout = &out
+		cmd.Stderr = &out
+		err := startChild(cmd)
+		if err == nil {
+			err = waitChild(cmd)
+		}
+		if err != nil {
+			return errors.Wrapf(err, "exec output %q", out.Bytes())
+		}
+		return nil
+	case pr.file != "":
+		_, err := os.Stat(pr.file)
+		return errors.Wrap(err, "stat file")
+	}
+	return errors.New("empty probe")
+}
+
+func newHealthCheckProbe(spec *pb.ProcessSpec) *probe {
+	hc := spec.Healthcheck
+	return &probe{httpGet: hc.HttpGet, tcpConnect: hc.TcpConnect, exec: hc.Exec, dir: spec.Directory, env: spec.Environment}
+}
+
+// runHealthCheck probes the process until it exits, switching it between RUNNING and UNHEALTHY
+// and restarting it after restart_threshold consecutive failures.
+func (p *processInstances) runHealthCheck(exited chan struct{}) {
+	hc := p.spec.Healthcheck
+	name := p.spec.ProcessName
+	interval := defaultHealthCheckInterval
+	if hc.Interval > 0 {
+		interval = time.Duration(hc.Interval * float32(time.Second))
+	}
+	timeout := defaultHealthCheckTimeout
+	if hc.Timeout > 0 {
+		timeout = time.Duration(hc.Timeout * float32(time.Second))
+	}
+	threshold := int32(defaultHealthCheckFailureThreshold)
+	if hc.FailureThreshold > 0 {
+		threshold = hc.FailureThreshold
+	}
+	pr := newHealthCheckProbe(p.spec)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	failures := int32(0)
+	for {
+		select {
+		case <-exited:
+			return
+		case <-ticker.C:
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		err := pr.check(ctx)
+		cancel()
+
+		p.lock.Lock()
+		if p.exited != exited {
+			p.lock.Unlock()
+			return
+		}
+		if err == nil {
+			failures = 0
+			p.healthy = true
+			if p.status.Status == pb.ProcessStatus_UNHEALTHY {
+				p.setStatus(pb.ProcessStatus_RUNNING, "ok")
+			}
+			p.lock.Unlock()
+			continue
+		}
+		failures++
+		logWarn("healthcheck failed", "process_name", name, "failures", failures, "err", err)
+		if failures >= threshold && p.status.Status == pb.ProcessStatus_RUNNING {
+			p.setStatus(pb.ProcessStatus_UNHEALTHY, "healthcheck failed: "+err.Error())
+		}
+		p.lock.Unlock()
+
+		if hc.RestartThreshold > 0 && failures >= hc.RestartThreshold {
+			logInfo("healthcheck restart process", "process_name", name, "failures", failures)
+			if err := p.restart(); err != nil {
+				logWarn("healthcheck restart process failed", "process_name", name, "err", err)
+				continue
+			}
+			return
+		}
+	}
+}
@@ -0,0 +1,205 @@
+package process
+
+import (
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	pb "github.com/wangkechun/gosupervisor/pkg/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const defaultHTTPLogTail = 16 * 1024
+
+var httpCommands = map[string]pb.CommandRequest_Command{
+	"start":   pb.CommandRequest_START,
+	"stop":    pb.CommandRequest_STOP,
+	"restart": pb.CommandRequest_RESTART,
+	"kill":    pb.CommandRequest_KILL,
+	"reload":  pb.CommandRequest_RELOAD,
+}
+
+// httpHandler exposes the grpc api as HTTP/JSON and serves the dashboard:
+//
+//	GET  /api/ping
+//	GET  /api/processes
+//	GET  /api/processes/{name}
+//	GET  /api/processes/{name}/log?stream={stdout|stderr}&bytes=N
+//	POST /api/processes/{name}/{start|stop|restart|kill|reload}, needs X-Requested-With or a JSON body
+//	GET  /api/events (server-sent events)
+//	POST /RPC2 (supervisord compatible xmlrpc)
+func (s *serverInstance) httpHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/ping", s.httpPing)
+	mux.HandleFunc("/api/processes", s.httpList)
+	mux.HandleFunc("/api/processes/", s.httpProcess)
+	mux.HandleFunc("/api/events", s.httpEvents)
+	mux.HandleFunc("/RPC2", s.httpXMLRPC)
+	mux.HandleFunc("/", httpDashboard)
+	return mux
+}
+
+func (s *serverInstance) httpPing(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeHTTPError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	resp, err := s.Ping(r.Context(), &pb.PingRequest{})
+	writeHTTPReply(w, resp, err)
+}
+
+func (s *serverInstance) httpList(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeHTTPError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	resp, err := s.List(r.Context(), &pb.ListRequest{})
+	writeHTTPReply(w, resp, err)
+}
+
+func (s *serverInstance) httpProcess(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/api/processes/"), "/")
+	name := parts[0]
+	switch {
+	case len(parts) == 1 && r.Method == http.MethodGet:
+		s.lock.RLock()
+		p, ok := s.process[name]
+		s.lock.RUnlock()
+		if !ok {
+			writeHTTPError(w, http.StatusNotFound, "process "+name+" not found")
+			return
+		}
+		st := p.readStatus()
+		writeHTTPReply(w, &st, nil)
+	case len(parts) == 2 && parts[1] == "log" && r.Method == http.MethodGet:
+		s.httpLog(w, r, name)
+	case len(parts) == 2 && r.Method == http.MethodPost:
+		if !httpNotSimple(r) {
+			writeHTTPError(w, http.StatusForbidden, "POST needs X-Requested-With header or application/json content type")
+			return
+		}
+		cmd, ok := httpCommands[parts[1]]
+		if !ok {
+			writeHTTPError(w, http.StatusNotFound, "unknown command "+parts[1])
+			return
+		}
+		resp, err := s.Command(r.Context(), &pb.CommandRequest{Command: cmd, ProcessName: name})
+		writeHTTPReply(w, resp, err)
+	case len(parts) <= 2:
+		writeHTTPError(w, http.StatusMethodNotAllowed, "method not allowed")
+	default:
+		writeHTTPError(w, http.StatusNotFound, "not found")
+	}
+}
+
+func (s *serverInstance) httpLog(w http.ResponseWriter, r *http.Request, name string) {
+	s.lock.RLock()
+	p, ok := s.process[name]
+	s.lock.RUnlock()
+	if !ok {
+		writeHTTPError(w, http.StatusNotFound, "process "+name+" not found")
+		return
+	}
+	buf := p.stdoutLog
+	switch r.FormValue("stream") {
+	case "", "stdout":
+	case "stderr":
+		buf = p.stderrLog
+	default:
+		writeHTTPError(w, http.StatusBadRequest, "unknown stream "+r.FormValue("stream"))
+		return
+	}
+	n := defaultHTTPLogTail
+	if v := r.FormValue("bytes"); v != "" {
+		var err error
+		n, err = strconv.Atoi(v)
+		if err != nil {
+			writeHTTPError(w, http.StatusBadRequest, "bad bytes "+v)
+			return
+		}
+	}
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Write(buf.tail(n))
+}
+
+func (s *serverInstance) httpEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeHTTPError(w, http.StatusInternalServerError, "streaming unsupported")
+		return
+	}
+	ch := s.events.subscribe()
+	defer s.events.unsubscribe(ch)
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	flusher.Flush()
+	m := jsonpb.Marshaler{EmitDefaults: true}
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case ev := <-ch:
+			data, err := m.MarshalToString(ev)
+			if err != nil {
+				continue
+			}
+			fmt.Fprintf(w, "data: %s\n\n", data)
+			flusher.Flush()
+		}
+	}
+}
+
+// httpNotSimple reports whether r can not be a CORS simple request. A cross site page can post
+// a form to the api without any preflight, but it can not set these headers.
+func httpNotSimple(r *http.Request) bool {
+	if r.Header.Get("X-Requested-With") != "" {
+		return true
+	}
+	ct, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	return ct == "application/json"
+}
+
+func writeHTTPReply(w http.ResponseWriter, resp proto.Message, err error) {
+	if err != nil {
+		st := status.Convert(err)
+		writeHTTPError(w, httpStatusFromCode(st.Code()), st.Message())
+		return
+	}
+	m := jsonpb.Marshaler{EmitDefaults: true}
+	w.Header().Set("Content-Type", "application/json")
+	if err := m.Marshal(w, resp); err != nil {
+		writeHTTPError(w, http.StatusInternalServerError, err.Error())
+	}
+}
+
+func writeHTTPError(w http.ResponseWriter, code int, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(map[string]string{"error": msg})
+}
+
+func httpStatusFromCode(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.InvalidArgument:
+		return http.StatusBadRequest
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.FailedPrecondition:
+		return http.StatusConflict
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,63 @@
+package process
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/stretchr/testify/assert"
+	pb "github.com/wangkechun/gosupervisor/pkg/proto"
+)
+
+func TestHTTPHandler(t *testing.T) {
+	a := assert.New(t)
+	s := newServerInstance(&pb.ConfigFile{Version: ServiceVersion, Process: []*pb.ProcessSpec{
+		{ProcessName: "sleep", Command: "sleep 60"},
+	}})
+	a.Nil(s.initLoad())
+	srv := httptest.NewServer(s.httpHandler())
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "/api/processes")
+	a.Nil(err)
+	a.Equal(http.StatusOK, resp.StatusCode)
+	var list pb.ListReply
+	a.Nil(jsonpb.Unmarshal(resp.Body, &list))
+	resp.Body.Close()
+	a.Len(list.Process, 1)
+	a.Equal("sleep", list.Process[0].Spec.ProcessName)
+
+	for _, c := range []struct {
+		method string
+		path   string
+		code   int
+	}{
+		{http.MethodGet, "/api/ping", http.StatusOK},
+		{http.MethodGet, "/api/processes/sleep", http.StatusOK},
+		{http.MethodGet, "/api/processes/none", http.StatusNotFound},
+		{http.MethodPost, "/api/processes/none/restart", http.StatusNotFound},
+		{http.MethodPost, "/api/processes/sleep/unknown", http.StatusNotFound},
+		{http.MethodGet, "/api/processes/sleep/restart", http.StatusMethodNotAllowed},
+		{http.MethodPost, "/api/processes", http.StatusMethodNotAllowed},
+	} {
+		req, err := http.NewRequest(c.method, srv.URL+c.path, nil)
+		a.Nil(err)
+		req.Header.Set("X-Requested-With", "test")
+		resp, err := http.DefaultClient.Do(req)
+		a.Nil(err)
+		resp.Body.Close()
+		a.Equal(c.code, resp.StatusCode, c.method+" "+c.path)
+	}
+
+	// a cross site form post is rejected
+	resp, err = http.Post(srv.URL+"/api/processes/sleep/start", "application/x-www-form-urlencoded", nil)
+	a.Nil(err)
+	resp.Body.Close()
+	a.Equal(http.StatusForbidden, resp.StatusCode)
+	a.Equal(pb.ProcessStatus_INIT, s.process["sleep"].readStatus().Status.Status)
+	resp, err = http.Post(srv.URL+"/api/processes/none/start", "application/json; charset=utf-8", nil)
+	a.Nil(err)
+	resp.Body.Close()
+	a.Equal(http.StatusNotFound, resp.StatusCode)
+}
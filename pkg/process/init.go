@@ -0,0 +1,213 @@
+package process
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"os/signal"
+	"sync"
+	"syscall"
+
+	"github.com/pkg/errors"
+	pb "github.com/wangkechun/gosupervisor/pkg/proto"
+)
+
+// children are the pids started with startChild and not waited yet,
+// the init reaper must leave them to cmd.Wait
+var children = struct {
+	sync.Mutex
+	pids map[int]bool
+}{pids: make(map[int]bool)}
+
+// startChild starts cmd, the child is registered before the reaper can see it exit
+func startChild(cmd *exec.Cmd) error {
+	children.Lock()
+	defer children.Unlock()
+	err := cmd.Start()
+	if err == nil {
+		children.pids[cmd.Process.Pid] = true
+	}
+	return err
+}
+
+// waitChild waits for a child started with startChild
+func waitChild(cmd *exec.Cmd) error {
+	err := cmd.Wait()
+	children.Lock()
+	delete(children.pids, cmd.Process.Pid)
+	children.Unlock()
+	return err
+}
+
+// exitCode returns the shell style exit code of a process, 128+N when killed by signal N
+func exitCode(state *os.ProcessState) int {
+	if state == nil {
+		return 1
+	}
+	if ws, ok := state.Sys().(syscall.WaitStatus); ok && ws.Signaled() {
+		return 128 + int(ws.Signal())
+	}
+	return state.ExitCode()
+}
+
+// shutdownGate keeps processes from being started once the daemon is shutting down
+type shutdownGate struct {
+	lock     sync.RWMutex
+	shutting bool
+}
+
+// enter reports whether a process may be started, leave must be called after it started
+func (g *shutdownGate) enter() bool {
+	if g == nil {
+		return true
+	}
+	g.lock.RLock()
+	if g.shutting {
+		g.lock.RUnlock()
+		return false
+	}
+	return true
+}
+
+func (g *shutdownGate) leave() {
+	if g != nil {
+		g.lock.RUnlock()
+	}
+}
+
+// close waits for the starts in progress and refuses the following ones
+func (g *shutdownGate) close() {
+	g.lock.Lock()
+	g.shutting = true
+	g.lock.Unlock()
+}
+
+func (g *shutdownGate) closed() bool {
+	if g == nil {
+		return false
+	}
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+	return g.shutting
+}
+
+// stopAll stops every running process concurrently, killing those which do not stop in time,
+// and returns the exit code of the main process. No process is started and the state file is
+// not written any more, so the processes are restored as they were before the daemon exited.
+func (s *serverInstance) stopAll() int {
+	s.shutdown.close()
+	s.lock.RLock()
+	var wg sync.WaitGroup
+	code := 0
+	for _, p := range s.process {
+		if !p.alive() {
+			continue
+		}
+		wg.Add(1)
+		go func(p *processInstances) {
+			defer wg.Done()
+			c := p.shutdown()
+			if p.spec.ProcessName == s.config.MainProcess {
+				code = c
+			}
+		}(p)
+	}
+	s.lock.RUnlock()
+	wg.Wait()
+	return code
+}
+
+// forwardSignal sends sig to every running process
+func (s *serverInstance) forwardSignal(sig syscall.Signal) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	for _, p := range s.process {
+		if !p.alive() {
+			continue
+		}
+		if err := p.signal(sig); err != nil {
+			logWarn("forward signal failed", "process_name", p.spec.ProcessName, "signal", sig, "err", err)
+		}
+	}
+}
+
+// mainExited returns the exit code of the main process once it is not running any more
+func (s *serverInstance) mainExited(ev *pb.ProcessEvent) (int, bool) {
+	if ev.ProcessName != s.config.MainProcess || ev.LogRule != "" {
+		return 0, false
+	}
+	switch ev.ToStatus {
+	case pb.ProcessStatus_EXITED, pb.ProcessStatus_BACKOFF, pb.ProcessStatus_FATAL,
+		pb.ProcessStatus_COMPLETED, pb.ProcessStatus_STOPPED:
+	default:
+		return 0, false
+	}
+	p := s.process[ev.ProcessName]
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	if p.cmd == nil || p.cmd.ProcessState == nil {
+		return int(ev.ExitCode), true
+	}
+	return exitCode(p.cmd.ProcessState), true
+}
+
+func (s *serverInstance) validateInit() error {
+	if s.config.MainProcess == "" {
+		return nil
+	}
+	if !s.config.Init {
+		return errors.New("main_process requires init")
+	}
+	if _, ok := s.process[s.config.MainProcess]; !ok {
+		return errors.Errorf("main_process %v not found", s.config.MainProcess)
+	}
+	return nil
+}
+
+// initSubreaper makes orphans of the processes reparent to the daemon, it must run before
+// any process is started. As pid 1 this is the default.
+func (s *serverInstance) initSubreaper() {
+	if os.Getpid() == 1 {
+		return
+	}
+	if err := setChildSubreaper(); err != nil {
+		logWarn("set child subreaper failed", "err", err)
+	}
+}
+
+// initRunInit reaps orphans, handles signals and exits with the main process,
+// exit is os.Exit except in tests
+func (s *serverInstance) initRunInit(ctx context.Context, ch chan *pb.ProcessEvent, exit func(code int)) {
+	defer s.events.unsubscribe(ch)
+	sigs := make(chan os.Signal, 16)
+	signal.Notify(sigs, initSignals...)
+	defer signal.Stop(sigs)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case sig := <-sigs:
+			switch {
+			case isChildSignal(sig):
+				reapOrphans()
+			case sig == syscall.SIGTERM || sig == syscall.SIGINT:
+				logInfo("init got signal, stop all processes", "signal", sig)
+				code := s.stopAll()
+				exit(code)
+				return
+			default:
+				logInfo("init forward signal", "signal", sig)
+				s.forwardSignal(sig.(syscall.Signal))
+			}
+		case ev := <-ch:
+			code, ok := s.mainExited(ev)
+			if !ok {
+				continue
+			}
+			logInfo("main process exited, stop all processes", "process_name", ev.ProcessName, "exit_code", code)
+			s.stopAll()
+			exit(code)
+			return
+		}
+	}
+}
@@ -0,0 +1,45 @@
+//go:build linux
+// +build linux
+
+package process
+
+import (
+	"io/ioutil"
+	"os"
+	"strconv"
+	"syscall"
+)
+
+const prSetChildSubreaper = 36
+
+func setChildSubreaper() error {
+	_, _, errno := syscall.RawSyscall(syscall.SYS_PRCTL, prSetChildSubreaper, 1, 0)
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+// zombieChildren lists zombie processes whose parent is the daemon
+func zombieChildren() []int {
+	dirs, err := ioutil.ReadDir("/proc")
+	if err != nil {
+		return nil
+	}
+	self := os.Getpid()
+	var pids []int
+	for _, d := range dirs {
+		pid, err := strconv.Atoi(d.Name())
+		if err != nil {
+			continue
+		}
+		stat, err := readProcStat(pid)
+		if err != nil {
+			continue
+		}
+		if stat.state == "Z" && stat.ppid == self {
+			pids = append(pids, pid)
+		}
+	}
+	return pids
+}
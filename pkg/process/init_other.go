@@ -0,0 +1,14 @@
+//go:build !linux
+// +build !linux
+
+package process
+
+import "github.com/pkg/errors"
+
+func setChildSubreaper() error {
+	return errors.New("child subreaper is only supported on linux")
+}
+
+func zombieChildren() []int {
+	return nil
+}
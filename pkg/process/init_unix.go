@@ -0,0 +1,36 @@
+//go:build !windows
+// +build !windows
+
+package process
+
+import (
+	"os"
+	"syscall"
+)
+
+// initSignals are handled by init, SIGCHLD reaps orphans, SIGTERM and SIGINT stop all processes
+// and the others are forwarded to the processes
+var initSignals = []os.Signal{syscall.SIGCHLD, syscall.SIGTERM, syscall.SIGINT, syscall.SIGHUP,
+	syscall.SIGQUIT, syscall.SIGUSR1, syscall.SIGUSR2, syscall.SIGWINCH}
+
+func isChildSignal(sig os.Signal) bool {
+	return sig == syscall.SIGCHLD
+}
+
+// reapOrphans waits for zombie children which were not started by startChild,
+// they are orphans reparented to the daemon
+func reapOrphans() {
+	children.Lock()
+	defer children.Unlock()
+	for _, pid := range zombieChildren() {
+		if children.pids[pid] {
+			continue
+		}
+		var ws syscall.WaitStatus
+		if _, err := syscall.Wait4(pid, &ws, syscall.WNOHANG, nil); err != nil {
+			logWarn("reap orphan failed", "pid", pid, "err", err)
+			continue
+		}
+		logDebug("reap orphan", "pid", pid, "exit_code", ws.ExitStatus())
+	}
+}
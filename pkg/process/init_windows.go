@@ -0,0 +1,18 @@
+//go:build windows
+// +build windows
+
+package process
+
+import (
+	"os"
+	"syscall"
+)
+
+// initSignals are handled by init, there are no orphans to reap on windows
+var initSignals = []os.Signal{syscall.SIGTERM, syscall.SIGINT}
+
+func isChildSignal(sig os.Signal) bool {
+	return false
+}
+
+func reapOrphans() {}
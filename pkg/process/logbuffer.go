@@ -0,0 +1,150 @@
+package process
+
+import (
+	"bytes"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const defaultLogBufferSize = 64 * 1024
+
+// maxLineLength splits lines without a newline for this long
+const maxLineLength = 64 * 1024
+
+const prefixTimeFormat = "2006-01-02 15:04:05.000"
+
+// logBuffer keeps the latest output of a process in memory
+type logBuffer struct {
+	lock    sync.Mutex
+	buf     []byte
+	size    int
+	written int64 // total bytes ever written
+}
+
+func newLogBuffer(size int) *logBuffer {
+	return &logBuffer{size: size}
+}
+
+func (b *logBuffer) Write(data []byte) (int, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.written += int64(len(data))
+	if len(data) >= b.size {
+		b.buf = append(b.buf[:0], data[len(data)-b.size:]...)
+		return len(data), nil
+	}
+	if drop := len(b.buf) + len(data) - b.size; drop > 0 {
+		b.buf = append(b.buf[:0], b.buf[drop:]...)
+	}
+	b.buf = append(b.buf, data...)
+	return len(data), nil
+}
+
+// tail returns at most the last n bytes
+func (b *logBuffer) tail(n int) []byte {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if n <= 0 || n > len(b.buf) {
+		n = len(b.buf)
+	}
+	return append([]byte(nil), b.buf[len(b.buf)-n:]...)
+}
+
+// read returns the bytes in [offset, offset+length) of everything ever written,
+// a negative offset counts from the end and length 0 reads to the end.
+// Bytes already dropped from the buffer are skipped.
+func (b *logBuffer) read(offset, length int64) ([]byte, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if length < 0 {
+		return nil, errors.New("negative length")
+	}
+	if offset < 0 {
+		offset += b.written
+		if offset < 0 {
+			offset = 0
+		}
+	}
+	if offset > b.written {
+		return nil, errors.Errorf("offset %v beyond log size %v", offset, b.written)
+	}
+	end := b.written
+	if length > 0 && offset+length < end {
+		end = offset + length
+	}
+	first := b.written - int64(len(b.buf))
+	if offset < first {
+		offset = first
+	}
+	if end < offset {
+		return []byte{}, nil
+	}
+	return append([]byte(nil), b.buf[offset-first:end-first]...), nil
+}
+
+// total returns the total bytes ever written
+func (b *logBuffer) total() int64 {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.written
+}
+
+// lineWriter calls emit with every complete line written to it, without the newline
+type lineWriter struct {
+	lock sync.Mutex
+	buf  []byte
+	emit func(line []byte)
+}
+
+func newLineWriter(emit func(line []byte)) *lineWriter {
+	return &lineWriter{emit: emit}
+}
+
+func (w *lineWriter) Write(data []byte) (int, error) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	w.buf = append(w.buf, data...)
+	start := 0
+	for {
+		i := bytes.IndexByte(w.buf[start:], '\n')
+		if i < 0 {
+			break
+		}
+		w.emit(w.buf[start : start+i])
+		start += i + 1
+	}
+	for len(w.buf)-start >= maxLineLength {
+		w.emit(w.buf[start : start+maxLineLength])
+		start += maxLineLength
+	}
+	w.buf = append(w.buf[:0], w.buf[start:]...)
+	return len(data), nil
+}
+
+// flush emits the last line which has no newline
+func (w *lineWriter) flush() {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if len(w.buf) > 0 {
+		w.emit(w.buf)
+		w.buf = w.buf[:0]
+	}
+}
+
+// newPrefixWriter writes every line to out as "TIME NAME | LINE", a line is written with
+// a single Write so lines of processes sharing out never interleave.
+func newPrefixWriter(out io.Writer, name string) *lineWriter {
+	return newLineWriter(func(line []byte) {
+		buf := make([]byte, 0, len(prefixTimeFormat)+len(name)+len(line)+5)
+		buf = time.Now().AppendFormat(buf, prefixTimeFormat)
+		buf = append(buf, ' ')
+		buf = append(buf, name...)
+		buf = append(buf, " | "...)
+		buf = append(buf, line...)
+		buf = append(buf, '\n')
+		out.Write(buf)
+	})
+}
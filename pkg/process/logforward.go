@@ -0,0 +1,254 @@
+package process
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+	pb "github.com/wangkechun/gosupervisor/pkg/proto"
+)
+
+const defaultSyslogAddress = "/dev/log"
+const defaultJournaldAddress = "/run/systemd/journal/socket"
+
+// forwardQueueSize is the number of lines waiting to be sent, more lines are dropped
+const forwardQueueSize = 1024
+
+var syslogFacilities = map[string]int{
+	"kern": 0, "user": 1, "mail": 2, "daemon": 3, "auth": 4, "syslog": 5, "lpr": 6, "news": 7,
+	"uucp": 8, "cron": 9, "authpriv": 10, "ftp": 11,
+	"local0": 16, "local1": 17, "local2": 18, "local3": 19, "local4": 20, "local5": 21, "local6": 22, "local7": 23,
+}
+
+var syslogPriorities = map[string]int{
+	"emerg": 0, "alert": 1, "crit": 2, "err": 3, "error": 3, "warning": 4, "warn": 4, "notice": 5, "info": 6, "debug": 7,
+}
+
+type forwardLine struct {
+	time     time.Time
+	priority int
+	pid      int32
+	text     string
+}
+
+// logForwarder sends output lines of a process to syslog or journald in the background,
+// so a slow or missing log server never blocks the process.
+type logForwarder struct {
+	name     string
+	spec     *pb.LogForward
+	network  string
+	address  string
+	tag      string
+	facility int
+	hostname string
+	pid      int32
+	dropped  int64
+	lock     sync.Mutex // guards closed and sending to lines
+	closed   bool
+	lines    chan forwardLine
+	conn     net.Conn
+	failing  bool
+	stdout   *lineWriter
+	stderr   *lineWriter
+}
+
+func newLogForwarder(spec *pb.ProcessSpec) (*logForwarder, error) {
+	lf := spec.LogForward
+	f := &logForwarder{
+		name:     spec.ProcessName,
+		spec:     lf,
+		network:  lf.Network,
+		address:  lf.Address,
+		tag:      lf.Tag,
+		facility: syslogFacilities["user"],
+		lines:    make(chan forwardLine, forwardQueueSize),
+	}
+	if f.tag == "" {
+		f.tag = spec.ProcessName
+	}
+	if lf.Facility != "" {
+		v, ok := syslogFacilities[strings.ToLower(lf.Facility)]
+		if !ok {
+			return nil, errors.Errorf("unknown syslog facility %q", lf.Facility)
+		}
+		f.facility = v
+	}
+	stdoutPriority, err := parsePriority(lf.StdoutPriority, "info")
+	if err != nil {
+		return nil, err
+	}
+	stderrPriority, err := parsePriority(lf.StderrPriority, "err")
+	if err != nil {
+		return nil, err
+	}
+	switch lf.Target {
+	case pb.LogForward_SYSLOG:
+		if f.network == "" {
+			f.network = "unix"
+		}
+		switch f.network {
+		case "unix", "udp", "tcp":
+		default:
+			return nil, errors.Errorf("unsupported syslog network %v", f.network)
+		}
+		if f.address == "" {
+			if f.network != "unix" {
+				return nil, errors.Errorf("syslog over %v needs address", f.network)
+			}
+			f.address = defaultSyslogAddress
+		}
+	case pb.LogForward_JOURNALD:
+		f.network = "unixgram"
+		if f.address == "" {
+			f.address = defaultJournaldAddress
+		}
+	}
+	f.hostname, _ = os.Hostname()
+	if f.hostname == "" {
+		f.hostname = "-"
+	}
+	f.stdout = newLineWriter(func(line []byte) { f.queue(stdoutPriority, line) })
+	f.stderr = newLineWriter(func(line []byte) { f.queue(stderrPriority, line) })
+	go f.run()
+	return f, nil
+}
+
+func parsePriority(s, def string) (int, error) {
+	if s == "" {
+		s = def
+	}
+	v, ok := syslogPriorities[strings.ToLower(s)]
+	if !ok {
+		return 0, errors.Errorf("unknown syslog priority %q", s)
+	}
+	return v, nil
+}
+
+// setPid sets the pid sent with the following lines
+func (f *logForwarder) setPid(pid int) {
+	atomic.StoreInt32(&f.pid, int32(pid))
+}
+
+func (f *logForwarder) queue(priority int, line []byte) {
+	l := forwardLine{time: time.Now(), priority: priority, pid: atomic.LoadInt32(&f.pid), text: string(line)}
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if f.closed {
+		return
+	}
+	select {
+	case f.lines <- l:
+	default:
+		atomic.AddInt64(&f.dropped, 1)
+	}
+}
+
+// flush sends the unterminated last lines, call it after the process exited
+func (f *logForwarder) flush() {
+	f.stdout.flush()
+	f.stderr.flush()
+}
+
+// close stops the forwarder once the queued lines are sent, later lines are dropped
+func (f *logForwarder) close() {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if !f.closed {
+		f.closed = true
+		close(f.lines)
+	}
+}
+
+func (f *logForwarder) run() {
+	defer func() {
+		if f.conn != nil {
+			f.conn.Close()
+		}
+	}()
+	for l := range f.lines {
+		err := f.send(l)
+		if err != nil && !f.failing {
+			logWarn("log forward failed", "process_name", f.name, "address", f.address, "err", err)
+		}
+		if err == nil && f.failing {
+			logInfo("log forward recovered", "process_name", f.name, "address", f.address)
+		}
+		f.failing = err != nil
+		if n := atomic.SwapInt64(&f.dropped, 0); n > 0 {
+			logWarn("log forward queue full, drop lines", "process_name", f.name, "count", n)
+		}
+	}
+}
+
+// send writes a line, reconnecting once if the connection is broken
+func (f *logForwarder) send(l forwardLine) error {
+	msg := f.format(l)
+	var err error
+	for i := 0; i < 2; i++ {
+		if f.conn == nil {
+			f.conn, err = f.dial()
+			if err != nil {
+				return err
+			}
+		}
+		_, err = f.conn.Write(msg)
+		if err == nil {
+			return nil
+		}
+		f.conn.Close()
+		f.conn = nil
+	}
+	return errors.Wrap(err, "write log")
+}
+
+func (f *logForwarder) dial() (net.Conn, error) {
+	network := f.network
+	if network == "unix" {
+		// /dev/log is a datagram socket on most systems
+		conn, err := net.Dial("unixgram", f.address)
+		if err == nil {
+			return conn, nil
+		}
+	}
+	conn, err := net.DialTimeout(network, f.address, time.Second*5)
+	return conn, errors.Wrapf(err, "dial %v %v", network, f.address)
+}
+
+// format encodes a line as a RFC5424 syslog message or a journald native protocol datagram
+func (f *logForwarder) format(l forwardLine) []byte {
+	if f.spec.Target == pb.LogForward_JOURNALD {
+		var b bytes.Buffer
+		fmt.Fprintf(&b, "MESSAGE=%s\nPRIORITY=%d\nSYSLOG_FACILITY=%d\nSYSLOG_IDENTIFIER=%s\n", l.text, l.priority, f.facility, f.tag)
+		if l.pid != 0 {
+			fmt.Fprintf(&b, "SYSLOG_PID=%d\n", l.pid)
+		}
+		return b.Bytes()
+	}
+	procid := "-"
+	if l.pid != 0 {
+		procid = fmt.Sprint(l.pid)
+	}
+	msg := fmt.Sprintf("<%d>1 %s %s %s %s - - %s", f.facility*8+l.priority,
+		l.time.Format("2006-01-02T15:04:05.000000Z07:00"), f.hostname, syslogToken(f.tag), procid, l.text)
+	if f.network == "tcp" {
+		// octet counting framing of RFC6587
+		msg = fmt.Sprintf("%d %s", len(msg), msg)
+	}
+	return []byte(msg)
+}
+
+// syslogToken replaces characters not allowed in RFC5424 header fields
+func syslogToken(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r <= ' ' || r > '~' {
+			return '_'
+		}
+		return r
+	}, s)
+}
@@ -0,0 +1,209 @@
+package process
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	pb "github.com/wangkechun/gosupervisor/pkg/proto"
+)
+
+type logLevel int
+
+const (
+	levelDebug logLevel = iota
+	levelInfo
+	levelWarn
+	levelError
+)
+
+var logLevelNames = []string{"DEBUG", "INFO", "WARN", "ERROR"}
+
+// currentLogLevel drops daemon logs below it
+var currentLogLevel = levelInfo
+
+func parseLogLevel(s string) (logLevel, error) {
+	for i, v := range logLevelNames {
+		if strings.EqualFold(s, v) {
+			return logLevel(i), nil
+		}
+	}
+	if strings.EqualFold(s, "warning") {
+		return levelWarn, nil
+	}
+	return 0, errors.Errorf("unknown log level %q", s)
+}
+
+// currentLogFormat is set from ConfigFile.log_format
+var currentLogFormat = pb.ConfigFile_TEXT
+
+// setLogFormat switches the daemon log format, JSON and LOGFMT carry their own time and caller
+func setLogFormat(format pb.ConfigFile_LogFormat) {
+	currentLogFormat = format
+	if format != pb.ConfigFile_TEXT {
+		log.SetFlags(0)
+	}
+}
+
+// logValue converts v to a value which marshals well, errors and Stringers become strings
+func logValue(v interface{}) interface{} {
+	switch x := v.(type) {
+	case nil:
+		return nil
+	case error:
+		return x.Error()
+	case fmt.Stringer:
+		return x.String()
+	case string, bool, int, int32, int64, uint, uint32, uint64, float32, float64, []string:
+		return x
+	}
+	return fmt.Sprint(v)
+}
+
+// logfmtValue quotes s when it is empty or contains spaces, quotes or =
+func logfmtValue(s string) string {
+	if s == "" || strings.IndexFunc(s, func(r rune) bool { return r <= ' ' || r == '=' || r == '"' }) >= 0 {
+		return strconv.Quote(s)
+	}
+	return s
+}
+
+// formatLog renders a log line, kv are key value pairs like "process_name", name
+func formatLog(format pb.ConfigFile_LogFormat, now time.Time, level logLevel, caller, msg string, kv []interface{}) string {
+	if len(kv)%2 != 0 {
+		kv = append(kv, nil)
+	}
+	var b strings.Builder
+	switch format {
+	case pb.ConfigFile_JSON:
+		b.WriteString("{")
+		write := func(k string, v interface{}) {
+			if b.Len() > 1 {
+				b.WriteString(",")
+			}
+			key, _ := json.Marshal(k)
+			value, err := json.Marshal(v)
+			if err != nil {
+				value, _ = json.Marshal(fmt.Sprint(v))
+			}
+			b.Write(key)
+			b.WriteString(":")
+			b.Write(value)
+		}
+		write("time", now.Format(time.RFC3339Nano))
+		write("level", logLevelNames[level])
+		write("caller", caller)
+		write("msg", msg)
+		for i := 0; i < len(kv); i += 2 {
+			write(fmt.Sprint(kv[i]), logValue(kv[i+1]))
+		}
+		b.WriteString("}")
+	case pb.ConfigFile_LOGFMT:
+		fmt.Fprintf(&b, "time=%s level=%s caller=%s msg=%s",
+			now.Format(time.RFC3339Nano), logLevelNames[level], logfmtValue(caller), logfmtValue(msg))
+		for i := 0; i < len(kv); i += 2 {
+			fmt.Fprintf(&b, " %s=%s", fmt.Sprint(kv[i]), logfmtValue(fmt.Sprint(logValue(kv[i+1]))))
+		}
+	default:
+		b.WriteString(logLevelNames[level])
+		b.WriteString(" ")
+		b.WriteString(msg)
+		for i := 0; i < len(kv); i += 2 {
+			fmt.Fprintf(&b, " %s=%v", fmt.Sprint(kv[i]), logValue(kv[i+1]))
+		}
+	}
+	return b.String()
+}
+
+func logOutput(level logLevel, msg string, kv []interface{}) {
+	if level < currentLogLevel {
+		return
+	}
+	caller := ""
+	// skip logOutput and logDebug, logInfo... to report their caller
+	if _, file, line, ok := runtime.Caller(2); ok {
+		caller = filepath.Base(file) + ":" + strconv.Itoa(line)
+	}
+	log.Output(3, formatLog(currentLogFormat, time.Now(), level, caller, msg, kv))
+}
+
+func logDebug(msg string, kv ...interface{}) { logOutput(levelDebug, msg, kv) }
+func logInfo(msg string, kv ...interface{})  { logOutput(levelInfo, msg, kv) }
+func logWarn(msg string, kv ...interface{})  { logOutput(levelWarn, msg, kv) }
+func logError(msg string, kv ...interface{}) { logOutput(levelError, msg, kv) }
+
+const defaultLogfileMaxBytes = 50 * 1024 * 1024
+const defaultLogfileBackups = 10
+
+// rotatingFile is a log file which is renamed to path.1, path.2... once it grows over maxBytes
+type rotatingFile struct {
+	lock     sync.Mutex
+	path     string
+	maxBytes int64
+	backups  int
+	file     *os.File
+	size     int64
+}
+
+func openRotatingFile(path string, maxBytes int64, backups int) (*rotatingFile, error) {
+	if maxBytes <= 0 {
+		maxBytes = defaultLogfileMaxBytes
+	}
+	if backups < 0 {
+		backups = defaultLogfileBackups
+	}
+	r := &rotatingFile{path: path, maxBytes: maxBytes, backups: backups}
+	if err := r.open(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *rotatingFile) open() error {
+	f, err := os.OpenFile(r.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	if err != nil {
+		return errors.Wrap(err, "open log file")
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return errors.Wrap(err, "stat log file")
+	}
+	r.file = f
+	r.size = info.Size()
+	return nil
+}
+
+func (r *rotatingFile) rotate() error {
+	r.file.Close()
+	if r.backups == 0 {
+		os.Remove(r.path)
+	} else {
+		for i := r.backups - 1; i > 0; i-- {
+			os.Rename(r.path+"."+strconv.Itoa(i), r.path+"."+strconv.Itoa(i+1))
+		}
+		os.Rename(r.path, r.path+".1")
+	}
+	return r.open()
+}
+
+func (r *rotatingFile) Write(data []byte) (int, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.size > 0 && r.size+int64(len(data)) > r.maxBytes {
+		if err := r.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := r.file.Write(data)
+	r.size += int64(n)
+	return n, err
+}
@@ -0,0 +1,119 @@
+package process
+
+import (
+	"bytes"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	pb "github.com/wangkechun/gosupervisor/pkg/proto"
+)
+
+const defaultLogRuleRateLimit = time.Minute
+
+// logRule is a compiled LogRule
+type logRule struct {
+	spec     *pb.LogRule
+	name     string
+	re       *regexp.Regexp
+	interval time.Duration
+	lock     sync.Mutex
+	last     time.Time
+}
+
+func newLogRules(spec *pb.ProcessSpec) ([]*logRule, error) {
+	rules := make([]*logRule, 0, len(spec.LogRules))
+	for i, v := range spec.LogRules {
+		re, err := regexp.Compile(v.Pattern)
+		if err != nil {
+			return nil, errors.Wrapf(err, "log rule %d pattern", i+1)
+		}
+		r := &logRule{spec: v, name: v.Name, re: re, interval: defaultLogRuleRateLimit}
+		if r.name == "" {
+			r.name = "rule" + strconv.Itoa(i+1)
+		}
+		if strings.ContainsAny(r.name, " \t\r\n") {
+			return nil, errors.Errorf("log rule name %q contains spaces", r.name)
+		}
+		if v.RateLimit > 0 {
+			r.interval = time.Duration(v.RateLimit * float32(time.Second))
+		}
+		switch v.Action {
+		case pb.LogRule_EXEC:
+			if v.Exec == "" {
+				return nil, errors.Errorf("log rule %v needs exec", r.name)
+			}
+		case pb.LogRule_WEBHOOK:
+			if len(v.Url) == 0 {
+				return nil, errors.Errorf("log rule %v needs url", r.name)
+			}
+		}
+		rules = append(rules, r)
+	}
+	return rules, nil
+}
+
+// allow reports whether the rule may act at now, it acts at most once per interval
+func (r *logRule) allow(now time.Time) bool {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if !r.last.IsZero() && now.Sub(r.last) < r.interval {
+		return false
+	}
+	r.last = now
+	return true
+}
+
+// matchOutput checks an output line of the process against its log rules
+func (p *processInstances) matchOutput(stream pb.LogRule_Stream, line []byte) {
+	for _, r := range p.logRules {
+		if r.spec.Stream != pb.LogRule_BOTH && r.spec.Stream != stream {
+			continue
+		}
+		if !r.re.Match(line) || !r.allow(time.Now()) {
+			continue
+		}
+		// this runs in the goroutine copying the output, which stop waits for while holding p.lock
+		go p.fireLogRule(r, string(line))
+	}
+}
+
+func (p *processInstances) fireLogRule(r *logRule, line string) {
+	name := p.spec.ProcessName
+	logInfo("log rule matched", "process_name", name, "rule", r.name, "action", r.spec.Action, "line", line)
+	p.lock.RLock()
+	ev := newProcessEvent(name, p.status.Status, p.status.Status)
+	if p.cmd != nil && p.cmd.Process != nil {
+		ev.Pid = int32(p.cmd.Process.Pid)
+	}
+	p.lock.RUnlock()
+	ev.LogRule = r.name
+	ev.LogLine = line
+	p.events.publish(ev)
+
+	switch r.spec.Action {
+	case pb.LogRule_EXEC:
+		cmd := p.command([]string{"sh", "-c", r.spec.Exec})
+		cmd.Env = p.environ("GOSUPERVISOR_PROCESS_NAME="+name, "GOSUPERVISOR_LOG_RULE="+r.name, "GOSUPERVISOR_LOG_LINE="+line)
+		var out bytes.Buffer
+		cmd.Stdout = &out
+		cmd.Stderr = &out
+		err := startChild(cmd)
+		if err == nil {
+			err = waitChild(cmd)
+		}
+		if err != nil {
+			logWarn("log rule exec failed", "process_name", name, "rule", r.name, "err", err, "output", out.String())
+		}
+	case pb.LogRule_WEBHOOK:
+		newNotifier(&pb.Notification{Url: r.spec.Url}).notify(pb.Notification_LOG_MATCH, ev)
+	case pb.LogRule_RESTART:
+		logInfo("log rule restart process", "process_name", name, "rule", r.name)
+		if err := p.restart(); err != nil {
+			logWarn("log rule restart process failed", "process_name", name, "err", err)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package process
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/stretchr/testify/assert"
+	pb "github.com/wangkechun/gosupervisor/pkg/proto"
+)
+
+func TestLogRuleRateLimit(t *testing.T) {
+	a := assert.New(t)
+	rules, err := newLogRules(&pb.ProcessSpec{LogRules: []*pb.LogRule{{Pattern: "x", RateLimit: 10}}})
+	a.Nil(err)
+	r := rules[0]
+	a.Equal("rule1", r.name)
+	now := time.Now()
+	a.True(r.allow(now))
+	a.False(r.allow(now.Add(time.Second * 9)))
+	a.True(r.allow(now.Add(time.Second * 10)))
+
+	for _, v := range []*pb.LogRule{
+		{Pattern: "("},
+		{Pattern: "x", Name: "has space"},
+		{Pattern: "x", Action: pb.LogRule_EXEC},
+		{Pattern: "x", Action: pb.LogRule_WEBHOOK},
+	} {
+		_, err := newLogRules(&pb.ProcessSpec{LogRules: []*pb.LogRule{v}})
+		a.NotNil(err, v.String())
+	}
+}
+
+func TestLogRuleActions(t *testing.T) {
+	a := assert.New(t)
+	dir, err := ioutil.TempDir("", "gosupervisor")
+	a.Nil(err)
+	defer os.RemoveAll(dir)
+
+	posted := make(chan *pb.NotificationPayload, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload pb.NotificationPayload
+		a.Nil(jsonpb.Unmarshal(r.Body, &payload))
+		posted <- &payload
+	}))
+	defer server.Close()
+
+	p, err := newProcessInstances(&pb.ProcessSpec{
+		ProcessName: "app",
+		Command:     `sh -c "echo java.lang.OutOfMemoryError; echo panic: oops >&2; echo panic: stdout; exec sleep 60"`,
+		Directory:   dir,
+		Startsecs:   0.1,
+		LogRules: []*pb.LogRule{
+			{Name: "oom", Pattern: "OutOfMemoryError", Action: pb.LogRule_EXEC, Exec: `echo "$GOSUPERVISOR_LOG_RULE $GOSUPERVISOR_LOG_LINE" > matched`},
+			{Name: "panic", Pattern: "^panic:", Stream: pb.LogRule_STDERR, Action: pb.LogRule_WEBHOOK, Url: []string{server.URL}},
+		},
+	})
+	a.Nil(err)
+	hub := newEventHub()
+	ch := hub.subscribe()
+	p.events = hub
+	a.Nil(p.start(startByManual))
+	defer p.kill()
+
+	payload := <-posted
+	a.Equal(pb.Notification_LOG_MATCH, payload.Trigger)
+	a.Equal("panic", payload.Event.LogRule)
+	a.Equal("panic: oops", payload.Event.LogLine)
+
+	matched := map[string]string{}
+	timeout := time.After(time.Second * 3)
+	for len(matched) < 2 {
+		select {
+		case ev := <-ch:
+			if ev.LogRule != "" {
+				matched[ev.LogRule] = ev.LogLine
+			}
+		case <-timeout:
+			t.Fatal("log rule events not published")
+		}
+	}
+	a.Equal(map[string]string{"oom": "java.lang.OutOfMemoryError", "panic": "panic: oops"}, matched)
+	for i := 0; i < 30; i++ {
+		if _, err := os.Stat(filepath.Join(dir, "matched")); err == nil {
+			break
+		}
+		time.Sleep(time.Millisecond * 100)
+	}
+	buf, err := ioutil.ReadFile(filepath.Join(dir, "matched"))
+	a.Nil(err)
+	a.Equal("oom java.lang.OutOfMemoryError\n", string(buf))
+}
+
+func TestLogRuleRestart(t *testing.T) {
+	a := assert.New(t)
+	p, err := newProcessInstances(&pb.ProcessSpec{
+		ProcessName: "app",
+		Command:     `sh -c "sleep 0.3; echo fatal; exec sleep 60"`,
+		Startsecs:   0.1,
+		Autostart:   true,
+		LogRules:    []*pb.LogRule{{Pattern: "fatal", Action: pb.LogRule_RESTART}},
+	})
+	a.Nil(err)
+	a.Nil(p.start(startByAuto))
+	defer p.kill()
+	pid := p.readStatus().Status.Pid
+	for i := 0; i < 30 && p.readStatus().Status.Pid == pid; i++ {
+		time.Sleep(time.Millisecond * 100)
+	}
+	a.NotEqual(pid, p.readStatus().Status.Pid)
+	// the restart is not a manual start, a daemon restart still follows autostart
+	a.Equal(pb.SavedProcess_UNSET, p.saveState().Desired)
+	// the restarted process matches again but is rate limited
+	time.Sleep(time.Millisecond * 600)
+	pid = p.readStatus().Status.Pid
+	time.Sleep(time.Millisecond * 300)
+	a.Equal(pid, p.readStatus().Status.Pid)
+}
@@ -0,0 +1,207 @@
+package process
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/pkg/errors"
+	pb "github.com/wangkechun/gosupervisor/pkg/proto"
+)
+
+const defaultNotifyTimeout = time.Second * 5
+const defaultNotifyRetries = 3
+const defaultFlappingCount = 5
+const defaultFlappingWindow = time.Minute
+const signatureHeader = "X-Gosupervisor-Signature"
+
+// notifyRetryInterval is doubled after every failed post
+var notifyRetryInterval = time.Second
+
+type notifier struct {
+	config         *pb.Notification
+	client         *http.Client
+	triggers       map[pb.Notification_Trigger]bool
+	processes      map[string]bool
+	flappingCount  int
+	flappingWindow time.Duration
+	lock           sync.Mutex
+	exits          map[string][]time.Time
+}
+
+func newNotifier(config *pb.Notification) *notifier {
+	n := &notifier{
+		config:         config,
+		client:         &http.Client{Timeout: defaultNotifyTimeout},
+		triggers:       make(map[pb.Notification_Trigger]bool),
+		processes:      make(map[string]bool),
+		flappingCount:  defaultFlappingCount,
+		flappingWindow: defaultFlappingWindow,
+		exits:          make(map[string][]time.Time),
+	}
+	if config.Timeout > 0 {
+		n.client.Timeout = time.Duration(config.Timeout * float32(time.Second))
+	}
+	if config.FlappingCount > 0 {
+		n.flappingCount = int(config.FlappingCount)
+	}
+	if config.FlappingWindow > 0 {
+		n.flappingWindow = time.Duration(config.FlappingWindow * float32(time.Second))
+	}
+	for _, v := range config.Triggers {
+		n.triggers[v] = true
+	}
+	for _, v := range config.ProcessName {
+		n.processes[v] = true
+	}
+	return n
+}
+
+func (n *notifier) enabled(trigger pb.Notification_Trigger) bool {
+	return len(n.triggers) == 0 || n.triggers[trigger]
+}
+
+// check returns the triggers fired by the event
+func (n *notifier) check(ev *pb.ProcessEvent, spec *pb.ProcessSpec) []pb.Notification_Trigger {
+	if len(n.processes) > 0 && !n.processes[ev.ProcessName] {
+		return nil
+	}
+	if ev.LogRule != "" {
+		if n.enabled(pb.Notification_LOG_MATCH) {
+			return []pb.Notification_Trigger{pb.Notification_LOG_MATCH}
+		}
+		return nil
+	}
+	var triggers []pb.Notification_Trigger
+	switch ev.ToStatus {
+	case pb.ProcessStatus_FATAL:
+		triggers = append(triggers, pb.Notification_FATAL)
+	case pb.ProcessStatus_EXITED:
+		if !isExpectedExitCode(spec, ev.ExitCode) {
+			triggers = append(triggers, pb.Notification_UNEXPECTED_EXIT)
+		}
+	}
+	if ev.ToStatus == pb.ProcessStatus_EXITED || ev.ToStatus == pb.ProcessStatus_BACKOFF {
+		if n.flapping(ev.ProcessName, time.Unix(0, ev.Timestamp*int64(time.Millisecond))) {
+			triggers = append(triggers, pb.Notification_FLAPPING)
+		}
+	}
+	result := triggers[:0]
+	for _, v := range triggers {
+		if n.enabled(v) {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+// flapping records an exit and reports whether the process exited too often recently,
+// the history is cleared after reporting so a flapping process is reported once per window.
+func (n *notifier) flapping(name string, t time.Time) bool {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	exits := append(n.exits[name], t)
+	for len(exits) > 0 && t.Sub(exits[0]) > n.flappingWindow {
+		exits = exits[1:]
+	}
+	if len(exits) >= n.flappingCount {
+		delete(n.exits, name)
+		return true
+	}
+	n.exits[name] = exits
+	return false
+}
+
+func (n *notifier) notify(trigger pb.Notification_Trigger, ev *pb.ProcessEvent) {
+	hostname, _ := os.Hostname()
+	m := jsonpb.Marshaler{EmitDefaults: true}
+	body, err := m.MarshalToString(&pb.NotificationPayload{Trigger: trigger, Hostname: hostname, Event: ev})
+	if err != nil {
+		logWarn("marshal notification failed", "err", err)
+		return
+	}
+	for _, url := range n.config.Url {
+		if err := n.post(url, []byte(body)); err != nil {
+			logWarn("send notification failed", "url", url, "trigger", trigger, "process_name", ev.ProcessName, "err", err)
+		}
+	}
+}
+
+func (n *notifier) post(url string, body []byte) error {
+	retries := defaultNotifyRetries
+	if n.config.Retries > 0 {
+		retries = int(n.config.Retries)
+	}
+	interval := notifyRetryInterval
+	var err error
+	for i := 0; i <= retries; i++ {
+		if i > 0 {
+			time.Sleep(interval)
+			interval *= 2
+		}
+		err = n.postOnce(url, body)
+		if err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
+func (n *notifier) postOnce(url string, body []byte) error {
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "new request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if n.config.HmacSecret != "" {
+		mac := hmac.New(sha256.New, []byte(n.config.HmacSecret))
+		mac.Write(body)
+		req.Header.Set(signatureHeader, "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	}
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "post")
+	}
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.Errorf("unexpected status %v", resp.Status)
+	}
+	return nil
+}
+
+// initRunNotifier sends the notifications of the events on ch
+func (s *serverInstance) initRunNotifier(ctx context.Context, ch chan *pb.ProcessEvent) {
+	defer s.events.unsubscribe(ch)
+	if len(s.config.Notifications) == 0 {
+		return
+	}
+	notifiers := make([]*notifier, 0, len(s.config.Notifications))
+	for _, v := range s.config.Notifications {
+		notifiers = append(notifiers, newNotifier(v))
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case ev := <-ch:
+			s.lock.RLock()
+			p, ok := s.process[ev.ProcessName]
+			s.lock.RUnlock()
+			if !ok {
+				continue
+			}
+			for _, n := range notifiers {
+				for _, trigger := range n.check(ev, p.spec) {
+					go n.notify(trigger, ev)
+				}
+			}
+		}
+	}
+}
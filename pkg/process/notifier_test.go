@@ -0,0 +1,72 @@
+package process
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/stretchr/testify/assert"
+	pb "github.com/wangkechun/gosupervisor/pkg/proto"
+)
+
+func TestNotifierCheck(t *testing.T) {
+	a := assert.New(t)
+	n := newNotifier(&pb.Notification{FlappingCount: 3, FlappingWindow: 10})
+	spec := &pb.ProcessSpec{ProcessName: "web", Exitcodes: []int32{0}}
+	now := time.Now().UnixNano() / int64(time.Millisecond)
+	exit := func(code int32, offset time.Duration) *pb.ProcessEvent {
+		return &pb.ProcessEvent{ProcessName: "web", ToStatus: pb.ProcessStatus_EXITED, ExitCode: code, Timestamp: now + int64(offset/time.Millisecond)}
+	}
+	a.Empty(n.check(exit(0, 0), spec))
+	a.Equal([]pb.Notification_Trigger{pb.Notification_UNEXPECTED_EXIT}, n.check(exit(1, time.Second), spec))
+	a.Equal([]pb.Notification_Trigger{pb.Notification_FLAPPING}, n.check(exit(0, 2*time.Second), spec))
+	// history is cleared after reporting flapping, exits outside the window are forgotten
+	a.Empty(n.check(exit(0, 3*time.Second), spec))
+	a.Empty(n.check(exit(0, 20*time.Second), spec))
+	a.Empty(n.check(exit(0, 21*time.Second), spec))
+
+	n = newNotifier(&pb.Notification{Triggers: []pb.Notification_Trigger{pb.Notification_FATAL}, ProcessName: []string{"web"}})
+	a.Empty(n.check(exit(1, 0), spec))
+	a.Equal([]pb.Notification_Trigger{pb.Notification_FATAL}, n.check(&pb.ProcessEvent{ProcessName: "web", ToStatus: pb.ProcessStatus_FATAL}, spec))
+	a.Empty(n.check(&pb.ProcessEvent{ProcessName: "db", ToStatus: pb.ProcessStatus_FATAL}, spec))
+}
+
+func TestNotifierPost(t *testing.T) {
+	a := assert.New(t)
+	defer func(v time.Duration) { notifyRetryInterval = v }(notifyRetryInterval)
+	notifyRetryInterval = time.Millisecond
+	requests := make(chan *pb.NotificationPayload, 10)
+	failures := 2
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		mac := hmac.New(sha256.New, []byte("secret"))
+		mac.Write(body)
+		a.Equal("sha256="+hex.EncodeToString(mac.Sum(nil)), r.Header.Get(signatureHeader))
+		if failures > 0 {
+			failures--
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		var payload pb.NotificationPayload
+		a.Nil(jsonpb.UnmarshalString(string(body), &payload))
+		requests <- &payload
+	}))
+	defer srv.Close()
+
+	n := newNotifier(&pb.Notification{Url: []string{srv.URL}, HmacSecret: "secret", Retries: 2})
+	n.notify(pb.Notification_FATAL, &pb.ProcessEvent{ProcessName: "web", ToStatus: pb.ProcessStatus_FATAL})
+	a.Len(requests, 1)
+	payload := <-requests
+	a.Equal(pb.Notification_FATAL, payload.Trigger)
+	a.Equal("web", payload.Event.ProcessName)
+
+	failures = 10
+	a.NotNil(n.post(srv.URL, []byte("{}")))
+	a.Equal(7, failures)
+}
@@ -0,0 +1,166 @@
+package process
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	pb "github.com/wangkechun/gosupervisor/pkg/proto"
+)
+
+const notifySocketEnv = "NOTIFY_SOCKET"
+const watchdogUsecEnv = "WATCHDOG_USEC"
+
+// notifySocket receives sd_notify messages from one run of a process
+type notifySocket struct {
+	path      string
+	conn      *net.UnixConn
+	handle    func(fields map[string]string)
+	ready     chan struct{} // closed on READY=1
+	readyOnce sync.Once
+	watchdog  chan struct{} // WATCHDOG=1 pings
+}
+
+func newNotifySocket(name string, handle func(fields map[string]string)) (*notifySocket, error) {
+	path := filepath.Join(os.TempDir(), fmt.Sprintf("gosupervisor-%d-%s.sock", os.Getpid(), name))
+	os.Remove(path)
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: path, Net: "unixgram"})
+	if err != nil {
+		return nil, errors.Wrap(err, "listen notify socket")
+	}
+	n := &notifySocket{path: path, conn: conn, handle: handle, ready: make(chan struct{}), watchdog: make(chan struct{}, 1)}
+	go n.serve()
+	return n, nil
+}
+
+// parseNotify parses newline separated KEY=VALUE assignments
+func parseNotify(msg []byte) map[string]string {
+	fields := make(map[string]string)
+	for _, line := range strings.Split(string(msg), "\n") {
+		kv := strings.SplitN(line, "=", 2)
+		if len(kv) == 2 {
+			fields[kv[0]] = kv[1]
+		}
+	}
+	return fields
+}
+
+func (n *notifySocket) serve() {
+	buf := make([]byte, 4096)
+	for {
+		nr, err := n.conn.Read(buf)
+		if err != nil {
+			return
+		}
+		fields := parseNotify(buf[:nr])
+		if fields["READY"] == "1" {
+			n.readyOnce.Do(func() { close(n.ready) })
+		}
+		if fields["WATCHDOG"] == "1" {
+			select {
+			case n.watchdog <- struct{}{}:
+			default:
+			}
+		}
+		if n.handle != nil {
+			n.handle(fields)
+		}
+	}
+}
+
+func (n *notifySocket) close() {
+	n.conn.Close()
+	os.Remove(n.path)
+}
+
+func (p *processInstances) needNotifySocket() bool {
+	return p.spec.Notify || (p.spec.Readiness != nil && p.spec.Readiness.Notify)
+}
+
+// handleNotify records STATUS= and MAINPID= sent by the run which exits with exited
+func (p *processInstances) handleNotify(exited chan struct{}, fields map[string]string) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.exited != exited {
+		return
+	}
+	if v, ok := fields["STATUS"]; ok {
+		p.notifyStatus = v
+	}
+	if v, ok := fields["MAINPID"]; ok {
+		pid, err := strconv.Atoi(v)
+		if err != nil {
+			logWarn("bad MAINPID", "process_name", p.spec.ProcessName, "value", v)
+		} else {
+			p.mainPid = pid
+		}
+	}
+}
+
+// signalMainPid signals the MAINPID reported by the process if it is not the process itself,
+// must be called with lock held
+func (p *processInstances) signalMainPid(sig os.Signal) {
+	if p.mainPid == 0 || p.cmd == nil || p.mainPid == p.cmd.Process.Pid {
+		return
+	}
+	proc, err := os.FindProcess(p.mainPid)
+	if err == nil {
+		err = proc.Signal(sig)
+	}
+	if err != nil {
+		logWarn("signal main pid failed", "process_name", p.spec.ProcessName, "pid", p.mainPid, "err", err)
+	}
+}
+
+// runWatchdog restarts the process when WATCHDOG=1 pings stop arriving
+func (p *processInstances) runWatchdog(exited chan struct{}, notify *notifySocket) {
+	timeout := time.Duration(p.spec.WatchdogSec * float32(time.Second))
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	for {
+		select {
+		case <-exited:
+			return
+		case <-notify.watchdog:
+			if !timer.Stop() {
+				<-timer.C
+			}
+			timer.Reset(timeout)
+		case <-timer.C:
+			logWarn("watchdog timeout, restart process", "process_name", p.spec.ProcessName, "timeout", timeout)
+			p.lock.RLock()
+			current := p.exited == exited
+			p.lock.RUnlock()
+			if !current {
+				return
+			}
+			if err := p.restart(); err != nil {
+				logWarn("watchdog restart process failed", "process_name", p.spec.ProcessName, "err", err)
+			}
+			return
+		}
+	}
+}
+
+// notifyEnv returns the environment variables for the notify socket
+func (p *processInstances) notifyEnv(notify *notifySocket) []string {
+	env := []string{notifySocketEnv + "=" + notify.path}
+	if p.spec.WatchdogSec > 0 {
+		usec := int64(p.spec.WatchdogSec * float32(time.Second) / float32(time.Microsecond))
+		env = append(env, watchdogUsecEnv+"="+strconv.FormatInt(usec, 10))
+	}
+	return env
+}
+
+func validateNotify(spec *pb.ProcessSpec) error {
+	if spec.WatchdogSec > 0 && !spec.Notify {
+		return errors.Errorf("watchdog_sec requires notify, name:%v", spec.ProcessName)
+	}
+	return nil
+}
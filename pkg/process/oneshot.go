@@ -0,0 +1,109 @@
+package process
+
+import (
+	"os/exec"
+	"time"
+
+	"github.com/pkg/errors"
+	pb "github.com/wangkechun/gosupervisor/pkg/proto"
+)
+
+const dependencyCheckInterval = time.Millisecond * 200
+
+// oneshotExited handles the exit of a oneshot process, it is COMPLETED on an expected exit code
+func (p *processInstances) oneshotExited(cmd *exec.Cmd) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.status.Status != pb.ProcessStatus_STARTING {
+		return
+	}
+	code := int32(cmd.ProcessState.ExitCode())
+	if !isExpectedExitCode(p.spec, code) {
+		logWarn("oneshot process failed", "process_name", p.spec.ProcessName, "pid", cmd.Process.Pid, "exit_code", code)
+		p.startFailed(cmd, cmd.ProcessState.String())
+		return
+	}
+	logInfo("oneshot process completed", "process_name", p.spec.ProcessName, "pid", cmd.Process.Pid)
+	p.lastExitCode = code
+	p.backoffTimes = 0
+	p.setStatus(pb.ProcessStatus_COMPLETED, cmd.ProcessState.String())
+}
+
+// dependencyReady reports whether a process depending on p may start
+func (p *processInstances) dependencyReady() bool {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	if p.spec.Type == pb.ProcessSpec_ONESHOT {
+		return p.status.Status == pb.ProcessStatus_COMPLETED
+	}
+	return p.status.Status == pb.ProcessStatus_RUNNING || p.status.Status == pb.ProcessStatus_UNHEALTHY
+}
+
+// checkDependencies returns an error naming the first dependency which is not ready
+func (p *processInstances) checkDependencies() error {
+	for _, d := range p.deps {
+		if !d.dependencyReady() {
+			return errors.Errorf("dependency %v is not ready", d.spec.ProcessName)
+		}
+	}
+	return nil
+}
+
+// waitDependencies waits until all dependencies are ready, it fails once one of them is FATAL
+func (p *processInstances) waitDependencies() error {
+	ticker := time.NewTicker(dependencyCheckInterval)
+	defer ticker.Stop()
+	for {
+		if p.checkDependencies() == nil {
+			return nil
+		}
+		for _, d := range p.deps {
+			if d.readStatus().Status.Status == pb.ProcessStatus_FATAL {
+				return errors.Errorf("dependency %v is FATAL", d.spec.ProcessName)
+			}
+		}
+		<-ticker.C
+	}
+}
+
+// resolveDependencies links depends_on of every process and rejects unknown names and cycles
+func (s *serverInstance) resolveDependencies() error {
+	for _, p := range s.process {
+		for _, name := range p.spec.DependsOn {
+			d, ok := s.process[name]
+			if !ok {
+				return errors.Errorf("process %v depends on unknown process %v", p.spec.ProcessName, name)
+			}
+			p.deps = append(p.deps, d)
+		}
+	}
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[*processInstances]int)
+	var visit func(p *processInstances) error
+	visit = func(p *processInstances) error {
+		switch state[p] {
+		case visiting:
+			return errors.Errorf("dependency cycle at process %v", p.spec.ProcessName)
+		case visited:
+			return nil
+		}
+		state[p] = visiting
+		for _, d := range p.deps {
+			if err := visit(d); err != nil {
+				return err
+			}
+		}
+		state[p] = visited
+		return nil
+	}
+	for _, p := range s.process {
+		if err := visit(p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package process
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	pb "github.com/wangkechun/gosupervisor/pkg/proto"
+)
+
+func TestOneshot(t *testing.T) {
+	a := assert.New(t)
+	migrate, err := newProcessInstances(&pb.ProcessSpec{ProcessName: "migrate", Command: "sleep 0.2", Type: pb.ProcessSpec_ONESHOT})
+	a.Nil(err)
+	app, err := newProcessInstances(&pb.ProcessSpec{ProcessName: "app", Command: "sleep 60", Startsecs: 0.1, DependsOn: []string{"migrate"}})
+	a.Nil(err)
+	s := serverInstance{process: map[string]*processInstances{"migrate": migrate, "app": app}}
+	a.Nil(s.resolveDependencies())
+
+	a.NotNil(app.start(startByManual))
+	a.Nil(migrate.start(startByManual))
+	a.Nil(app.waitDependencies())
+	a.Equal(pb.ProcessStatus_COMPLETED, migrate.readStatus().Status.Status)
+	a.Nil(app.start(startByManual))
+	a.Nil(app.waitHealthy())
+	app.kill()
+
+	// a completed oneshot process is not restarted by monitor
+	a.Nil(migrate.start(startByMonitor))
+	a.Equal(pb.ProcessStatus_COMPLETED, migrate.readStatus().Status.Status)
+
+	failed, err := newProcessInstances(&pb.ProcessSpec{ProcessName: "failed", Command: "false", Type: pb.ProcessSpec_ONESHOT})
+	a.Nil(err)
+	a.Nil(failed.start(startByManual))
+	<-failed.exited
+	time.Sleep(time.Millisecond * 100)
+	a.Equal(pb.ProcessStatus_BACKOFF, failed.readStatus().Status.Status)
+}
+
+func TestResolveDependencies(t *testing.T) {
+	a := assert.New(t)
+	newProc := func(name string, deps ...string) *processInstances {
+		p, err := newProcessInstances(&pb.ProcessSpec{ProcessName: name, Command: "true", DependsOn: deps})
+		a.Nil(err)
+		return p
+	}
+	s := serverInstance{process: map[string]*processInstances{"a": newProc("a", "b"), "b": newProc("b", "c")}}
+	a.NotNil(s.resolveDependencies())
+	s = serverInstance{process: map[string]*processInstances{"a": newProc("a", "b"), "b": newProc("b", "a")}}
+	a.NotNil(s.resolveDependencies())
+	s = serverInstance{process: map[string]*processInstances{"a": newProc("a", "b", "c"), "b": newProc("b", "c"), "c": newProc("c")}}
+	a.Nil(s.resolveDependencies())
+}
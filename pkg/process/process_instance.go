@@ -1,7 +1,8 @@
 package process
 
 import (
-	"log"
+	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"sync"
@@ -16,11 +17,16 @@ import (
 const defaultProcessWaitTime = time.Second * 3     // 进程启动3秒之后，认为是成功启动
 const defaultProcessStopTimeout = time.Second * 30 // 停止进程最多等待30s
 
+// outputDrainTimeout bounds reading the output left in the pipes after the process exited,
+// a child of the process may keep them open
+const outputDrainTimeout = time.Second
+
 type startMode int
 
 const startByAuto startMode = 1    // supervisord 启动的时候启动
 const startByManual startMode = 2  // 手动重启
 const startByMonitor startMode = 3 // 被monitor线程重启
+const startByRestart startMode = 4 // 被 supervisor 内部启动或重启，不改变 desired
 
 type processInstances struct {
 	spec         *pb.ProcessSpec
@@ -31,69 +37,191 @@ type processInstances struct {
 	monitorLock  sync.RWMutex // start 和 monitor 过程的锁
 	lastExitCode int32
 	backoffTimes int32
+	events       *eventHub
+	startGate    *shutdownGate // shared by the processes of a server, nil in tests
+	listener     *eventListener
+	stdoutLog    *logBuffer
+	stderrLog    *logBuffer
+	lastSample   resourceSample
+	stoppedAt    time.Time
+	exited       chan struct{} // closed when the current cmd exited
+	notifyStatus string        // STATUS= sent by sd_notify
+	mainPid      int           // MAINPID= sent by sd_notify
+	sockets      []*boundSocket
+	healthy      bool // passed a healthcheck since started
+	deps         []*processInstances
+	desired      pb.SavedProcess_Desired // set by manual start and stop
+	forwarder    *logForwarder
+	stdout       io.Writer // the daemon stdout, or a prefixWriter on it
+	stderr       io.Writer
+	logRules     []*logRule
+	rulesStdout  *lineWriter // feeds stdout lines to logRules
+	rulesStderr  *lineWriter
+	output       *outputHub     // output of the process for Attach
+	stdin        io.WriteCloser // stdin pipe of the current run when spec.Stdin
+	pty          *ptyConsole    // pseudo-terminal of the current run when spec.Pty
+	ptyRows      uint16
+	ptyCols      uint16
+	outputDir    string // output goes through named pipes made here when set, so the process can be adopted
+}
+
+// setStatus changes the process status and publishes the transition, must be called with p.lock held.
+func (p *processInstances) setStatus(status pb.ProcessStatus_Status, desc string) {
+	from := p.status.Status
+	p.status.Status = status
+	p.status.ProcessDesc = desc
+	if from == status {
+		return
+	}
+	switch status {
+	case pb.ProcessStatus_STOPPED, pb.ProcessStatus_EXITED, pb.ProcessStatus_BACKOFF, pb.ProcessStatus_FATAL, pb.ProcessStatus_COMPLETED:
+		p.stoppedAt = time.Now()
+		if p.spec.Schedule != "" {
+			p.status.LastRunResult = desc
+		}
+	}
+	ev := newProcessEvent(p.spec.ProcessName, from, status)
+	ev.ProcessDesc = desc
+	ev.ExitCode = p.lastExitCode
+	if p.cmd != nil && p.cmd.Process != nil {
+		ev.Pid = int32(p.cmd.Process.Pid)
+	}
+	logInfo("process status changed", "process_name", ev.ProcessName, "pid", ev.Pid,
+		"from", from, "status", status, "exit_code", ev.ExitCode, "desc", desc)
+	p.events.publish(ev)
 }
 
 func (p *processInstances) stop() error {
 	p.lock.Lock()
 	defer p.lock.Unlock()
+	p.desired = pb.SavedProcess_STOPPED
+	return p.stopLocked()
+}
+
+// stopLocked interrupts the process and waits for it to exit, must be called with p.lock held.
+func (p *processInstances) stopLocked() error {
 	name := p.spec.ProcessName
-	log.Println("interrupt process", name)
+	logInfo("interrupt process", "process_name", name)
 	if p.cmd == nil {
 		return errors.New("process not exists")
 	}
-	if p.cmd.ProcessState != nil {
-		if p.cmd.ProcessState.Exited() {
-			return errors.New("process alread stoped")
-		}
+	select {
+	case <-p.exited:
+		return errors.New("process alread stoped")
+	default:
 	}
 	err := p.cmd.Process.Signal(os.Interrupt)
-	p.status.ProcessDesc = "process stopping"
-	p.status.Status = pb.ProcessStatus_STOPPING
-	log.Println("interrupt process done", name)
+	p.signalMainPid(os.Interrupt)
+	p.setStatus(pb.ProcessStatus_STOPPING, "process stopping")
+	logDebug("interrupt process done", "process_name", name)
 	if err != nil {
 		return errors.Wrap(err, "interrupt process")
 	}
-	for i := 0; i < 100; i++ {
-		time.Sleep(defaultProcessStopTimeout / 100)
-		if p.cmd != nil && p.cmd.ProcessState != nil && p.cmd.ProcessState.Exited() {
-			p.status.ProcessDesc = "process stoped"
-			p.status.Status = pb.ProcessStatus_STOPPED
-			p.cmd = nil
-			log.Println("process stoped")
-			return nil
-		}
+	select {
+	case <-p.exited:
+		p.setStatus(pb.ProcessStatus_STOPPED, "process stoped")
+		p.cmd = nil
+		logDebug("process stoped", "process_name", name)
+		return nil
+	case <-time.After(defaultProcessStopTimeout):
+		return errors.New("stop process timeout")
 	}
-	return errors.New("stop process timeout")
 }
 
 func (p *processInstances) kill() error {
 	p.lock.Lock()
 	defer p.lock.Unlock()
+	p.desired = pb.SavedProcess_STOPPED
+	return p.killLocked()
+}
+
+// killLocked kills the process, must be called with p.lock held.
+func (p *processInstances) killLocked() error {
 	if p.cmd == nil {
 		return errors.New("process not exists")
 	}
 	err := p.cmd.Process.Kill()
+	p.signalMainPid(os.Kill)
 	if err != nil {
 		return errors.Wrap(err, "kill process")
 	}
+	p.setStatus(pb.ProcessStatus_STOPPED, "process killed")
 	p.cmd = nil
-	p.status.ProcessDesc = "process killed"
-	p.status.Status = pb.ProcessStatus_STOPPED
 	return nil
 }
 
-func (p *processInstances) watchProcess() {
+// restart stops and starts the process on behalf of the supervisor itself,
+// unlike a manual restart it keeps desired.
+func (p *processInstances) restart() error {
+	p.lock.Lock()
+	err := p.stopLocked()
+	p.lock.Unlock()
+	if err != nil {
+		return errors.Wrap(err, "stop process")
+	}
+	return errors.Wrap(p.start(startByRestart), "start process")
+}
+
+// manualRestart is a restart asked for by the user, like a manual start it records the process as started
+func (p *processInstances) manualRestart() error {
+	if err := p.stop(); err != nil {
+		return err
+	}
+	return p.start(startByManual)
+}
+
+// shutdown stops the process for the daemon exiting, killing it if it does not stop in time,
+// and returns its exit code. Unlike stop it keeps desired, so the process starts again with the daemon.
+func (p *processInstances) shutdown() int {
+	p.lock.Lock()
+	cmd, exited := p.cmd, p.exited
+	if err := p.stopLocked(); err != nil {
+		logWarn("stop process failed, kill it", "process_name", p.spec.ProcessName, "err", err)
+		p.killLocked()
+	}
+	code := p.lastExitCode
+	p.lock.Unlock()
+	if cmd == nil {
+		return int(code)
+	}
+	<-exited
+	if cmd.ProcessState == nil {
+		// an adopted process is not our child, its exit code is unknown
+		return int(code)
+	}
+	return exitCode(cmd.ProcessState)
+}
+
+// watchProcess waits for cmd to exit and closes exited after that
+func (p *processInstances) watchProcess(cmd *exec.Cmd, exited chan struct{}, notify *notifySocket, console *ptyConsole, fifos *outputFifos) {
 	defer p.monitorLock.Unlock()
+	if notify != nil {
+		defer notify.close()
+	}
 	exitCh := make(chan error)
 	processWaitTime := defaultProcessWaitTime
 	if p.spec.Startsecs != 0 {
-		processWaitTime = time.Duration(p.spec.Startsecs) * time.Second
+		processWaitTime = time.Duration(p.spec.Startsecs * float32(time.Second))
 	}
 
 	go func() {
-		err := p.cmd.Wait()
+		err := waitChild(cmd)
+		if console != nil {
+			console.wait()
+		}
+		if fifos != nil {
+			fifos.wait()
+		}
+		p.flushOutput()
+		close(exited)
 		exitCh <- err
 	}()
+	if p.spec.Type == pb.ProcessSpec_ONESHOT {
+		<-exitCh
+		p.oneshotExited(cmd)
+		return
+	}
+	ready := p.waitReady(exited, notify)
 	select {
 	case <-exitCh:
 		// process exited too quickly
@@ -102,41 +230,76 @@ func (p *processInstances) watchProcess() {
 		if p.status.Status != pb.ProcessStatus_STARTING {
 			return
 		}
-		log.Printf("process start failed, name:%v, exit status:%v", p.spec.ProcessName, p.cmd.ProcessState.String())
-		p.lastExitCode = int32(p.cmd.ProcessState.ExitCode())
-		p.status.Status = pb.ProcessStatus_BACKOFF
-		p.backoffTimes++
-		if p.backoffTimes == p.spec.Startretries && p.spec.Startretries > 0 {
-			p.status.Status = pb.ProcessStatus_FATAL
+		logWarn("process start failed", "process_name", p.spec.ProcessName, "pid", cmd.Process.Pid, "exit_code", cmd.ProcessState.ExitCode(), "desc", cmd.ProcessState.String())
+		p.startFailed(cmd, cmd.ProcessState.String())
+	case <-ready:
+		logInfo("process ready", "process_name", p.spec.ProcessName, "pid", cmd.Process.Pid)
+		p.running(exitCh, exited, notify)
+	case <-time.After(processWaitTime):
+		if ready == nil {
+			logInfo("process alive", "process_name", p.spec.ProcessName, "pid", cmd.Process.Pid, "startsecs", processWaitTime)
+			p.running(exitCh, exited, notify)
+			return
 		}
-		if p.cmd != nil {
-			p.status.ProcessDesc = p.cmd.ProcessState.String()
+		logWarn("process not ready, kill it", "process_name", p.spec.ProcessName, "pid", cmd.Process.Pid, "startsecs", processWaitTime)
+		p.lock.Lock()
+		if p.status.Status == pb.ProcessStatus_STARTING {
+			cmd.Process.Kill()
 		}
-	case <-time.After(processWaitTime):
-		p.backoffTimes = 0
-		log.Println("process alive 3s", p.spec.ProcessName)
-		{
-			p.lock.Lock()
-			p.status.Status = pb.ProcessStatus_RUNNING
-			p.status.ProcessDesc = "ok"
-			p.lock.Unlock()
-		}
-
-		{
-			err := <-exitCh
-			p.lock.Lock()
-			defer p.lock.Unlock()
-			if p.status.Status == pb.ProcessStatus_STOPPING || p.status.Status == pb.ProcessStatus_STOPPED {
-				return
-			}
-			p.lastExitCode = int32(p.cmd.ProcessState.ExitCode())
-			p.status.Status = pb.ProcessStatus_EXITED
-			if p.cmd != nil {
-				p.status.ProcessDesc = p.cmd.ProcessState.String()
-			}
-			log.Println("process exit", p.spec.ProcessName, err)
+		p.lock.Unlock()
+		<-exitCh
+		p.lock.Lock()
+		defer p.lock.Unlock()
+		if p.status.Status != pb.ProcessStatus_STARTING {
+			return
 		}
+		p.startFailed(cmd, fmt.Sprintf("not ready in %v", processWaitTime))
+	}
+}
+
+// startFailed moves an exited STARTING process to BACKOFF or FATAL, must be called with p.lock held.
+func (p *processInstances) startFailed(cmd *exec.Cmd, desc string) {
+	p.lastExitCode = int32(cmd.ProcessState.ExitCode())
+	if p.spec.Schedule != "" {
+		// a scheduled job finishing quickly is a complete run
+		p.setStatus(pb.ProcessStatus_EXITED, cmd.ProcessState.String())
+		return
 	}
+	p.backoffTimes++
+	status := pb.ProcessStatus_BACKOFF
+	if p.backoffTimes == p.spec.Startretries && p.spec.Startretries > 0 {
+		status = pb.ProcessStatus_FATAL
+	}
+	p.setStatus(status, desc)
+}
+
+// running marks a started process RUNNING and waits for it to exit
+func (p *processInstances) running(exitCh chan error, exited chan struct{}, notify *notifySocket) {
+	p.lock.Lock()
+	if p.status.Status != pb.ProcessStatus_STARTING {
+		p.lock.Unlock()
+		<-exitCh
+		return
+	}
+	p.backoffTimes = 0
+	p.setStatus(pb.ProcessStatus_RUNNING, "ok")
+	p.lock.Unlock()
+	if p.spec.Healthcheck != nil {
+		go p.runHealthCheck(exited)
+	}
+	if p.spec.WatchdogSec > 0 {
+		go p.runWatchdog(exited, notify)
+	}
+
+	err := <-exitCh
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.status.Status == pb.ProcessStatus_STOPPING || p.status.Status == pb.ProcessStatus_STOPPED {
+		return
+	}
+	p.lastExitCode = int32(p.cmd.ProcessState.ExitCode())
+	p.setStatus(pb.ProcessStatus_EXITED, p.cmd.ProcessState.String())
+	logInfo("process exit", "process_name", p.spec.ProcessName, "pid", p.cmd.Process.Pid, "exit_code", p.lastExitCode, "err", err)
 }
 
 func (p *processInstances) start(mode startMode) error {
@@ -147,15 +310,24 @@ func (p *processInstances) start(mode startMode) error {
 			p.monitorLock.Unlock()
 		}
 	}()
+	// a process started while stopAll runs would be left behind when the daemon exits,
+	// the gate is entered after monitorLock which is held until the previous run exited
+	if !p.startGate.enter() {
+		return errors.New("daemon is shutting down")
+	}
+	defer p.startGate.leave()
 	p.lock.Lock()
 	defer p.lock.Unlock()
 
 	switch mode {
 	case startByAuto:
-		if !p.spec.Autostart {
+		if !p.autostart() {
 			return nil
 		}
 	case startByManual:
+		p.desired = pb.SavedProcess_STARTED
+		p.backoffTimes = 0
+	case startByRestart:
 		p.backoffTimes = 0
 	case startByMonitor:
 		shouldContinue := false
@@ -189,36 +361,219 @@ func (p *processInstances) start(mode startMode) error {
 		if !shouldContinue {
 			return nil
 		}
+		p.status.RestartedCount++
+	}
+	if err := p.checkDependencies(); err != nil {
+		return err
 	}
 
 	if p.cmd != nil {
-		if p.cmd.ProcessState == nil || !p.cmd.ProcessState.Exited() {
+		select {
+		case <-p.exited:
+		default:
 			return errors.New("process alread started")
 		}
 	}
-	log.Println("exec.CommandContext", p.spec.ProcessName, p.args)
-	p.cmd = exec.Command(p.args[0], p.args[1:]...)
-	p.cmd.Stderr = os.Stderr
-	p.cmd.Stdout = os.Stdout
-	p.cmd.Dir = p.spec.Directory
-	p.cmd.Env = p.spec.Environment
-	p.status.Status = pb.ProcessStatus_STARTING
-	p.status.ProcessDesc = "starting"
-	err := p.cmd.Start()
-	if err != nil {
-		p.status.Status = pb.ProcessStatus_BACKOFF
-		p.backoffTimes++
-		if p.backoffTimes == p.spec.Startretries && p.spec.Startretries > 0 {
-			p.status.Status = pb.ProcessStatus_FATAL
+	if len(p.spec.Listen) > 0 && len(p.sockets) == 0 {
+		// not bound yet when the process was adopted, the adopted process held them
+		sockets, err := bindSockets(p.spec)
+		if err != nil {
+			return p.startError(err)
+		}
+		p.sockets = sockets
+	}
+	logDebug("exec command", "process_name", p.spec.ProcessName, "args", p.args)
+	args := p.args
+	var env []string
+	if len(p.sockets) > 0 {
+		args = listenArgs(args)
+		env = append(env, listenEnv(p.sockets)...)
+	}
+	p.cmd = p.command(args)
+	stdout, stderr := p.outputWriters()
+	p.cmd.Stdout = stdout
+	p.cmd.Stderr = stderr
+	p.stdin = nil
+	p.notifyStatus = ""
+	p.mainPid = 0
+	p.healthy = false
+	exited := make(chan struct{})
+	var notify *notifySocket
+	if p.needNotifySocket() {
+		var err error
+		notify, err = newNotifySocket(p.spec.ProcessName, func(fields map[string]string) {
+			p.handleNotify(exited, fields)
+		})
+		if err != nil {
+			return errors.Wrap(err, "create notify socket")
 		}
-		p.status.ProcessDesc = "start failed " + err.Error()
-		return errors.Wrap(err, "process start")
+		env = append(env, p.notifyEnv(notify)...)
 	}
+	if len(env) > 0 {
+		p.cmd.Env = p.environ(env...)
+	}
+	p.cmd.ExtraFiles = listenFiles(p.sockets)
+	var serveListener func(started bool)
+	if p.listener != nil {
+		var err error
+		serveListener, err = p.listener.attach(p.cmd)
+		if err != nil {
+			if notify != nil {
+				notify.close()
+			}
+			return errors.Wrap(err, "attach eventlistener")
+		}
+	}
+	p.pty = nil
+	if p.spec.Pty {
+		console, slave, err := newPtyConsole(p.cmd, p.ptyRows, p.ptyCols)
+		if err != nil {
+			if notify != nil {
+				notify.close()
+			}
+			return errors.Wrap(err, "pty")
+		}
+		// the child has its own copy once started
+		defer slave.Close()
+		p.pty = console
+		if p.spec.Stdin {
+			p.stdin = console.master
+		}
+	}
+	var fifos *outputFifos
+	if p.outputDir != "" && !p.spec.Pty && p.listener == nil {
+		f, files, err := newOutputFifos(p.cmd, p.outputDir, p.spec.ProcessName)
+		if err != nil {
+			if notify != nil {
+				notify.close()
+			}
+			return errors.Wrap(err, "output fifo")
+		}
+		// the child has its own copies once started
+		defer closeFiles(files)
+		fifos = f
+	}
+	// the pipe is created last, a failed start closes it
+	var err error
+	if p.spec.Stdin && !p.spec.Pty {
+		p.stdin, err = p.cmd.StdinPipe()
+		err = errors.Wrap(err, "stdin pipe")
+	}
+	if err == nil {
+		err = startChild(p.cmd)
+	}
+	if serveListener != nil {
+		serveListener(err == nil)
+	}
+	if err != nil && notify != nil {
+		notify.close()
+	}
+	if err != nil && p.pty != nil {
+		p.pty.master.Close()
+		p.pty = nil
+	}
+	if err != nil && fifos != nil {
+		fifos.close()
+	}
+	if err != nil {
+		return p.startError(err)
+	}
+	if p.forwarder != nil {
+		p.forwarder.setPid(p.cmd.Process.Pid)
+	}
+	if p.pty != nil {
+		p.pty.copyOutput(stdout)
+	}
+	if fifos != nil {
+		fifos.copyOutput(stdout, stderr)
+	}
+	p.status.LastStartedTime = int32(time.Now().Unix())
+	p.setStatus(pb.ProcessStatus_STARTING, "starting")
 	shouldUnlockMonitor = false
-	go p.watchProcess()
+	p.exited = exited
+	go p.watchProcess(p.cmd, exited, notify, p.pty, fifos)
 	return nil
 }
 
+// startError moves a process which failed to start to BACKOFF, or FATAL once it ran out of retries,
+// must be called with lock held
+func (p *processInstances) startError(err error) error {
+	p.backoffTimes++
+	status := pb.ProcessStatus_BACKOFF
+	if p.backoffTimes == p.spec.Startretries && p.spec.Startretries > 0 {
+		status = pb.ProcessStatus_FATAL
+	}
+	p.setStatus(status, "start failed "+err.Error())
+	return errors.Wrap(err, "process start")
+}
+
+// outputWriters returns where the output of the process goes
+func (p *processInstances) outputWriters() (stdout, stderr io.Writer) {
+	outs := []io.Writer{p.stdout, p.stdoutLog, p.output.writer(pb.AttachReply_STDOUT)}
+	errs := []io.Writer{p.stderr, p.stderrLog, p.output.writer(pb.AttachReply_STDERR)}
+	if p.forwarder != nil {
+		outs = append(outs, p.forwarder.stdout)
+		errs = append(errs, p.forwarder.stderr)
+	}
+	if len(p.logRules) > 0 {
+		outs = append(outs, p.rulesStdout)
+		errs = append(errs, p.rulesStderr)
+	}
+	return io.MultiWriter(outs...), io.MultiWriter(errs...)
+}
+
+func closeFiles(files []*os.File) {
+	for _, f := range files {
+		f.Close()
+	}
+}
+
+// flushOutput writes the unterminated last lines, call it after the process exited
+func (p *processInstances) flushOutput() {
+	for _, w := range []io.Writer{p.stdout, p.stderr, p.rulesStdout, p.rulesStderr} {
+		if lw, ok := w.(*lineWriter); ok && lw != nil {
+			lw.flush()
+		}
+	}
+	if p.forwarder != nil {
+		p.forwarder.flush()
+	}
+}
+
+// autostart reports whether the process is started with the daemon,
+// a manual start or stop before the daemon restarted overrides autostart.
+func (p *processInstances) autostart() bool {
+	if p.spec.Schedule != "" {
+		return false
+	}
+	switch p.desired {
+	case pb.SavedProcess_STARTED:
+		return true
+	case pb.SavedProcess_STOPPED:
+		return false
+	}
+	return p.spec.Autostart
+}
+
+// command builds a command running in the directory and environment of the process
+func (p *processInstances) command(args []string) *exec.Cmd {
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Dir = p.spec.Directory
+	cmd.Env = p.spec.Environment
+	cmd.WaitDelay = outputDrainTimeout
+	return cmd
+}
+
+// environ returns the process environment with extra variables appended,
+// the daemon environment is inherited when environment is not configured.
+func (p *processInstances) environ(extra ...string) []string {
+	env := p.spec.Environment
+	if len(env) == 0 {
+		env = os.Environ()
+	}
+	return append(append([]string(nil), env...), extra...)
+}
+
 func (p *processInstances) readStatus() pb.Process {
 	p.lock.RLock()
 	defer p.lock.RUnlock()
@@ -227,11 +582,25 @@ func (p *processInstances) readStatus() pb.Process {
 	if p.cmd != nil {
 		status.Pid = int32(p.cmd.Process.Pid)
 	}
+	if p.mainPid != 0 {
+		status.Pid = int32(p.mainPid)
+	}
+	if p.notifyStatus != "" && (status.Status == pb.ProcessStatus_STARTING || status.Status == pb.ProcessStatus_RUNNING) {
+		status.ProcessDesc = p.notifyStatus
+	}
 	return pb.Process{Spec: &spec, Status: &status}
 }
 
 func newProcessInstances(spec *pb.ProcessSpec) (*processInstances, error) {
-	p := &processInstances{spec: spec, status: pb.ProcessStatus{}}
+	p := &processInstances{
+		spec:      spec,
+		status:    pb.ProcessStatus{},
+		stdoutLog: newLogBuffer(defaultLogBufferSize),
+		stderrLog: newLogBuffer(defaultLogBufferSize),
+		stdout:    os.Stdout,
+		stderr:    os.Stderr,
+		output:    newOutputHub(),
+	}
 	args, err := shellwords.Parse(spec.Command)
 	if err != nil {
 		return nil, errors.Errorf("shell command is incorrect, name:%v err:%v command:%v", spec.ProcessName, err, spec.Command)
@@ -240,5 +609,54 @@ func newProcessInstances(spec *pb.ProcessSpec) (*processInstances, error) {
 		return nil, errors.Errorf("shell command empty")
 	}
 	p.args = args
+	if spec.Readiness != nil && !spec.Readiness.Notify && newReadinessProbe(spec).empty() {
+		return nil, errors.Errorf("readiness needs one of tcp_connect, file, http_get or notify, name:%v", spec.ProcessName)
+	}
+	if err := validateNotify(spec); err != nil {
+		return nil, err
+	}
+	if err := validatePty(spec); err != nil {
+		return nil, err
+	}
+	if spec.Schedule != "" {
+		if _, err := parseCron(spec.Schedule); err != nil {
+			return nil, errors.Wrapf(err, "schedule, name:%v", spec.ProcessName)
+		}
+	}
+	if _, err := reloadSignal(spec); err != nil {
+		return nil, errors.Wrapf(err, "reloadsignal, name:%v", spec.ProcessName)
+	}
+	if spec.Healthcheck != nil && newHealthCheckProbe(spec).empty() {
+		return nil, errors.Errorf("healthcheck needs one of http_get, tcp_connect or exec, name:%v", spec.ProcessName)
+	}
+	if len(spec.Events) > 0 {
+		if spec.Stdin {
+			return nil, errors.Errorf("stdin of eventlistener %v is used by the eventlistener protocol", spec.ProcessName)
+		}
+		p.listener = newEventListener(spec)
+	}
+	p.logRules, err = newLogRules(spec)
+	if err != nil {
+		return nil, errors.Wrapf(err, "log_rules, name:%v", spec.ProcessName)
+	}
+	p.rulesStdout = newLineWriter(func(line []byte) { p.matchOutput(pb.LogRule_STDOUT, line) })
+	p.rulesStderr = newLineWriter(func(line []byte) { p.matchOutput(pb.LogRule_STDERR, line) })
+	if spec.LogForward != nil {
+		p.forwarder, err = newLogForwarder(spec)
+		if err != nil {
+			return nil, errors.Wrapf(err, "log_forward, name:%v", spec.ProcessName)
+		}
+	}
 	return p, nil
 }
+
+// close releases the log forwarder and listen sockets of a process instance which is discarded,
+// the process must not be running.
+func (p *processInstances) close() {
+	if p.forwarder != nil {
+		p.forwarder.close()
+	}
+	for _, v := range p.sockets {
+		v.close()
+	}
+}
@@ -1,11 +1,25 @@
 package process
 
 import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
 	"testing"
+	"time"
 
 	"github.com/golang/protobuf/jsonpb"
 	"github.com/golang/protobuf/proto"
 	"github.com/mattn/go-shellwords"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	pb "github.com/wangkechun/gosupervisor/pkg/proto"
 )
@@ -75,3 +89,573 @@ func TestShellwordsParse(t *testing.T) {
 	a.Nil(err)
 	a.Equal(args, []string{"sh", "-c", "aa"})
 }
+
+func TestProcessEvent(t *testing.T) {
+	a := assert.New(t)
+	hub := newEventHub()
+	ch := hub.subscribe()
+	defer hub.unsubscribe(ch)
+	p, err := newProcessInstances(&pb.ProcessSpec{ProcessName: "echo", Command: "echo"})
+	a.Nil(err)
+	p.events = hub
+	p.lastExitCode = 3
+	p.setStatus(pb.ProcessStatus_BACKOFF, "exit status 3")
+	p.setStatus(pb.ProcessStatus_BACKOFF, "exit status 3")
+	ev := <-ch
+	a.Equal("echo", ev.ProcessName)
+	a.Equal(pb.ProcessStatus_INIT, ev.FromStatus)
+	a.Equal(pb.ProcessStatus_BACKOFF, ev.ToStatus)
+	a.Equal(int32(3), ev.ExitCode)
+	a.Len(ch, 0)
+
+	match := watchFilter([]string{"echo"})
+	a.True(match(ev))
+	a.False(match(&pb.ProcessEvent{ProcessName: "ps"}))
+	a.True(watchFilter(nil)(ev))
+}
+
+func TestLogBuffer(t *testing.T) {
+	a := assert.New(t)
+	b := newLogBuffer(8)
+	b.Write([]byte("hello"))
+	a.Equal("hello", string(b.tail(0)))
+	a.Equal("llo", string(b.tail(3)))
+	b.Write([]byte(" world"))
+	a.Equal("lo world", string(b.tail(100)))
+	b.Write([]byte("0123456789"))
+	a.Equal("23456789", string(b.tail(0)))
+	a.Equal(int64(21), b.written)
+}
+
+func TestProbe(t *testing.T) {
+	a := assert.New(t)
+	ctx := context.Background()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/health" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer srv.Close()
+	a.Nil((&probe{httpGet: srv.URL + "/health"}).check(ctx))
+	a.NotNil((&probe{httpGet: srv.URL + "/other"}).check(ctx))
+	a.Nil((&probe{tcpConnect: srv.Listener.Addr().String()}).check(ctx))
+	a.Nil((&probe{exec: "test -d .", dir: "/tmp"}).check(ctx))
+	a.NotNil((&probe{exec: "exit 1"}).check(ctx))
+	a.True((&probe{}).empty())
+
+	_, err := newProcessInstances(&pb.ProcessSpec{ProcessName: "web", Command: "sleep 1", Healthcheck: &pb.HealthCheck{Interval: 1}})
+	a.NotNil(err)
+}
+
+func TestExitWithChildHoldingOutput(t *testing.T) {
+	a := assert.New(t)
+	p, err := newProcessInstances(&pb.ProcessSpec{ProcessName: "web", Command: `sh -c "sleep 5 & sleep 0.3"`, Startsecs: 0.1})
+	a.Nil(err)
+	p.events = newEventHub()
+	a.Nil(p.start(startByManual))
+	exited := p.exited
+	select {
+	case <-exited:
+	case <-time.After(time.Second * 3):
+		t.Fatal("exit not seen while a child holds stdout")
+	}
+	time.Sleep(time.Millisecond * 100)
+	a.Equal(pb.ProcessStatus_EXITED, p.readStatus().Status.Status)
+}
+
+func TestSampleResource(t *testing.T) {
+	a := assert.New(t)
+	p, err := newProcessInstances(&pb.ProcessSpec{ProcessName: "web", Command: "sleep 0.3", Startsecs: 0.1})
+	a.Nil(err)
+	p.events = newEventHub()
+	a.Nil(p.start(startByManual))
+	time.Sleep(time.Millisecond * 100)
+	p.sampleResource()
+	a.NotZero(p.readStatus().Status.MemoryUsage)
+	// sampling while the process exits must not race with cmd.Wait
+	exited := p.exited
+	for done := false; !done; {
+		select {
+		case <-exited:
+			done = true
+		default:
+			p.sampleResource()
+		}
+	}
+	p.sampleResource()
+	a.Zero(p.readStatus().Status.MemoryUsage)
+}
+
+func TestHealthCheckRestart(t *testing.T) {
+	a := assert.New(t)
+	p, err := newProcessInstances(&pb.ProcessSpec{ProcessName: "web", Command: "sleep 60", Startsecs: 0.1, Autostart: true,
+		Healthcheck: &pb.HealthCheck{Exec: "exit 1", Interval: 0.1, RestartThreshold: 2}})
+	a.Nil(err)
+	a.Nil(p.start(startByAuto))
+	defer p.kill()
+	pid := p.readStatus().Status.Pid
+	for i := 0; i < 30 && p.readStatus().Status.Pid == pid; i++ {
+		time.Sleep(time.Millisecond * 100)
+	}
+	a.NotEqual(pid, p.readStatus().Status.Pid)
+	a.Equal(pb.SavedProcess_UNSET, p.saveState().Desired)
+}
+
+func TestNotifyReady(t *testing.T) {
+	a := assert.New(t)
+	a.Equal(map[string]string{"READY": "1", "STATUS": "a=b"}, parseNotify([]byte("READY=1\nSTATUS=a=b\nbad")))
+
+	handled := make(chan map[string]string, 2)
+	n, err := newNotifySocket("test", func(fields map[string]string) { handled <- fields })
+	a.Nil(err)
+	defer n.close()
+	conn, err := net.Dial("unixgram", n.path)
+	a.Nil(err)
+	defer conn.Close()
+	_, err = conn.Write([]byte("READY=1\nSTATUS=serving"))
+	a.Nil(err)
+	select {
+	case <-n.ready:
+	case <-time.After(time.Second):
+		a.Fail("not ready")
+	}
+	a.Equal("serving", (<-handled)["STATUS"])
+	_, err = conn.Write([]byte("WATCHDOG=1"))
+	a.Nil(err)
+	select {
+	case <-n.watchdog:
+	case <-time.After(time.Second):
+		a.Fail("no watchdog ping")
+	}
+}
+
+func TestValidateNotify(t *testing.T) {
+	a := assert.New(t)
+	a.NotNil(validateNotify(&pb.ProcessSpec{ProcessName: "a", WatchdogSec: 1}))
+	a.Nil(validateNotify(&pb.ProcessSpec{ProcessName: "a", WatchdogSec: 1, Notify: true}))
+}
+
+func TestListenSocket(t *testing.T) {
+	a := assert.New(t)
+	_, err := bindSockets(&pb.ProcessSpec{Listen: []*pb.ListenSocket{{Network: "udp", Address: "127.0.0.1:0"}}})
+	a.NotNil(err)
+
+	sockets, err := bindSockets(&pb.ProcessSpec{Listen: []*pb.ListenSocket{{Address: "127.0.0.1:0", Name: "web"}}})
+	a.Nil(err)
+	defer sockets[0].close()
+	args := listenArgs([]string{"sh", "-c", `test "$LISTEN_PID" = "$$" && echo $LISTEN_FDS $LISTEN_FDNAMES`})
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Env = listenEnv(sockets)
+	cmd.ExtraFiles = listenFiles(sockets)
+	out, err := cmd.Output()
+	a.Nil(err)
+	a.Equal("1 web\n", string(out))
+
+	// the socket stays open after the process exited
+	conn, err := net.Dial("tcp", sockets[0].listener.Addr().String())
+	a.Nil(err)
+	conn.Close()
+}
+
+func TestRollingRestart(t *testing.T) {
+	a := assert.New(t)
+	newProc := func(name, command string) *processInstances {
+		p, err := newProcessInstances(&pb.ProcessSpec{ProcessName: name, Command: command, Startsecs: 0.2})
+		a.Nil(err)
+		a.Nil(p.start(startByManual))
+		a.Nil(p.waitHealthy())
+		return p
+	}
+	p1 := newProc("a", "sleep 60")
+	p2 := newProc("b", "sleep 60")
+	defer p1.kill()
+	defer p2.kill()
+	pid1 := p1.readStatus().Status.Pid
+	pid2 := p2.readStatus().Status.Pid
+	p1.desired = pb.SavedProcess_UNSET
+	a.Nil(rollingRestart([]*processInstances{p1, p2}, 1))
+	a.NotEqual(pid1, p1.readStatus().Status.Pid)
+	// recorded as started like a plain restart
+	a.Equal(pb.SavedProcess_STARTED, p1.saveState().Desired)
+	a.NotEqual(pid2, p2.readStatus().Status.Pid)
+	a.Equal(pb.ProcessStatus_RUNNING, p2.readStatus().Status.Status)
+
+	// p3 exits before startsecs, so p2 is not restarted
+	p3 := newProc("c", "sleep 60")
+	p3.spec.Command = "false"
+	p3.args = []string{"false"}
+	pid2 = p2.readStatus().Status.Pid
+	a.NotNil(rollingRestart([]*processInstances{p3, p2}, 1))
+	a.Equal(pid2, p2.readStatus().Status.Pid)
+}
+
+func TestParseSignal(t *testing.T) {
+	a := assert.New(t)
+	for _, v := range []string{"HUP", "SIGHUP", "hup", "1"} {
+		sig, err := parseSignal(v)
+		a.Nil(err)
+		a.Equal(syscall.SIGHUP, sig)
+	}
+	_, err := parseSignal("NOPE")
+	a.NotNil(err)
+	sig, err := reloadSignal(&pb.ProcessSpec{})
+	a.Nil(err)
+	a.Equal(syscall.SIGHUP, sig)
+}
+
+func TestStateFile(t *testing.T) {
+	a := assert.New(t)
+	dir, err := ioutil.TempDir("", "gosupervisor")
+	a.Nil(err)
+	defer os.RemoveAll(dir)
+	newServer := func() *serverInstance {
+		config := &pb.ConfigFile{
+			Version:   ServiceVersion,
+			StateFile: filepath.Join(dir, "state.json"),
+			Process: []*pb.ProcessSpec{
+				{ProcessName: "auto", Command: "true", Autostart: true},
+				{ProcessName: "manual", Command: "true"},
+			},
+		}
+		s := newServerInstance(config)
+		a.Nil(s.initLoad())
+		a.Nil(s.initRestoreState())
+		return s
+	}
+	s := newServer()
+	a.True(s.process["auto"].autostart())
+	a.False(s.process["manual"].autostart())
+	s.process["auto"].stop()
+	s.process["manual"].desired = pb.SavedProcess_STARTED
+	s.process["manual"].status.RestartedCount = 3
+	a.Nil(s.saveState())
+
+	s = newServer()
+	a.False(s.process["auto"].autostart())
+	a.Equal(pb.ProcessStatus_INIT, s.process["auto"].status.Status)
+	a.True(s.process["manual"].autostart())
+	a.Equal(int32(3), s.process["manual"].status.RestartedCount)
+}
+
+func TestAdopt(t *testing.T) {
+	a := assert.New(t)
+	cmd := exec.Command("sleep", "60")
+	a.Nil(cmd.Start())
+	go cmd.Wait()
+	id, err := readProcIdentity(cmd.Process.Pid)
+	a.Nil(err)
+	a.Equal([]string{"sleep", "60"}, id.cmdline)
+	st := &pb.SavedProcess{ProcessName: "sleep", Status: pb.ProcessStatus_RUNNING, Pid: int32(cmd.Process.Pid), StartTime: id.startTime, Cmdline: id.cmdline}
+
+	p, err := newProcessInstances(&pb.ProcessSpec{ProcessName: "sleep", Command: "sleep 60"})
+	a.Nil(err)
+	st.StartTime++
+	a.False(p.adopt(st))
+	st.StartTime--
+	a.True(p.adopt(st))
+	a.True(p.alive())
+	a.Equal(pb.ProcessStatus_RUNNING, p.readStatus().Status.Status)
+	a.Equal(int32(cmd.Process.Pid), p.readStatus().Status.Pid)
+	a.Nil(p.stop())
+	a.Equal(pb.ProcessStatus_STOPPED, p.readStatus().Status.Status)
+}
+
+func TestAdoptOutput(t *testing.T) {
+	a := assert.New(t)
+	dir, err := ioutil.TempDir("", "gosupervisor")
+	a.Nil(err)
+	defer os.RemoveAll(dir)
+	stateFile := filepath.Join(dir, "state.json")
+	// the adopted process holds the listen socket it got from the previous daemon
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	a.Nil(err)
+	defer ln.Close()
+	command := `sh -c 'i=0; while true; do echo line$i; i=$((i+1)); sleep 0.05; done'`
+	args, err := shellwords.Parse(command)
+	a.Nil(err)
+
+	// started by the previous daemon, which exits and stops reading the output
+	a.Nil(os.MkdirAll(stateFile+".output", 0700))
+	cmd := exec.Command(args[0], args[1:]...)
+	fifos, files, err := newOutputFifos(cmd, stateFile+".output", "writer")
+	a.Nil(err)
+	a.Nil(cmd.Start())
+	closeFiles(files)
+	fifos.close()
+	exitCh := make(chan error, 1)
+	go func() { exitCh <- cmd.Wait() }()
+	time.Sleep(300 * time.Millisecond)
+	select {
+	case err := <-exitCh:
+		t.Fatalf("process exited while no daemon reads its output: %v", err)
+	default:
+	}
+	id, err := readProcIdentity(cmd.Process.Pid)
+	a.Nil(err)
+	var buf bytes.Buffer
+	state := pb.SavedState{Process: []*pb.SavedProcess{{ProcessName: "writer", Status: pb.ProcessStatus_RUNNING,
+		Pid: int32(cmd.Process.Pid), StartTime: id.startTime, Cmdline: id.cmdline}}}
+	a.Nil((&jsonpb.Marshaler{}).Marshal(&buf, &state))
+	a.Nil(ioutil.WriteFile(stateFile, buf.Bytes(), 0644))
+
+	s := newServerInstance(&pb.ConfigFile{
+		Version:   ServiceVersion,
+		StateFile: stateFile,
+		Process: []*pb.ProcessSpec{{ProcessName: "writer", Command: command,
+			Listen: []*pb.ListenSocket{{Address: ln.Addr().String()}}}},
+	})
+	a.Nil(s.initLoad())
+	a.Nil(s.initRestoreState())
+	p := s.process["writer"]
+	a.True(p.alive())
+	a.Nil(s.initBindSockets())
+	a.Empty(p.sockets)
+	// the output written while no daemon ran was kept in the pipe
+	for i := 0; i < 30 && !strings.Contains(string(p.stdoutLog.tail(4096)), "line10"); i++ {
+		time.Sleep(100 * time.Millisecond)
+	}
+	out := string(p.stdoutLog.tail(4096))
+	a.Contains(out, "line0\n")
+	a.Contains(out, "line10\n")
+	a.Nil(p.stop())
+	a.Equal(pb.ProcessStatus_STOPPED, p.readStatus().Status.Status)
+	<-exitCh
+}
+
+func TestInitReap(t *testing.T) {
+	a := assert.New(t)
+	if os.Getenv("GOSUPERVISOR_TEST_SUBREAPER") == "" {
+		// being a subreaper can not be undone, so the check runs in a child test binary
+		cmd := exec.Command(os.Args[0], "-test.run=^TestInitReap$", "-test.v")
+		cmd.Env = append(os.Environ(), "GOSUPERVISOR_TEST_SUBREAPER=1")
+		out, err := cmd.CombinedOutput()
+		a.Nil(err, string(out))
+		a.Contains(string(out), "--- PASS: TestInitReap")
+		return
+	}
+	a.Nil(setChildSubreaper())
+	cmd := exec.Command("sh", "-c", "sleep 0.1 & exit 3")
+	a.Nil(startChild(cmd))
+	a.NotNil(waitChild(cmd))
+	a.Equal(3, exitCode(cmd.ProcessState))
+	time.Sleep(time.Millisecond * 300)
+	a.NotEmpty(zombieChildren())
+	reapOrphans()
+	a.Empty(zombieChildren())
+
+	cmd = exec.Command("sh", "-c", "kill -TERM $$")
+	a.Nil(startChild(cmd))
+	a.NotNil(waitChild(cmd))
+	a.Equal(128+int(syscall.SIGTERM), exitCode(cmd.ProcessState))
+}
+
+func TestStopAll(t *testing.T) {
+	a := assert.New(t)
+	s := newServerInstance(&pb.ConfigFile{Version: ServiceVersion, Init: true, MainProcess: "main", Process: []*pb.ProcessSpec{
+		{ProcessName: "main", Command: `sh -c "trap 'exit 7' INT; while true; do sleep 0.1; done"`, Startsecs: 0.1},
+		{ProcessName: "web", Command: "sleep 60", Startsecs: 0.1},
+	}})
+	a.Nil(s.initLoad())
+	for _, p := range s.process {
+		a.Nil(p.start(startByManual))
+		a.Nil(p.waitHealthy())
+	}
+	a.Equal(7, s.stopAll())
+	for _, p := range s.process {
+		a.False(p.alive())
+		// processes stopped with the daemon start again with it
+		a.Equal(pb.SavedProcess_STARTED, p.saveState().Desired)
+		// nothing is started any more while the daemon exits
+		a.NotNil(p.start(startByManual))
+		a.False(p.alive())
+	}
+}
+
+func TestDaemonFiles(t *testing.T) {
+	a := assert.New(t)
+	dir, err := ioutil.TempDir("", "gosupervisor")
+	a.Nil(err)
+	defer os.RemoveAll(dir)
+
+	pidfile := filepath.Join(dir, "gs.pid")
+	a.Nil(writePidfile(pidfile))
+	a.Nil(writePidfile(pidfile))
+	cmd := exec.Command("sleep", "60")
+	a.Nil(cmd.Start())
+	a.Nil(ioutil.WriteFile(pidfile, []byte(strconv.Itoa(cmd.Process.Pid)), 0644))
+	a.NotNil(writePidfile(pidfile))
+	cmd.Process.Kill()
+	cmd.Wait()
+	a.Nil(writePidfile(pidfile))
+
+	logfile := filepath.Join(dir, "gs.log")
+	f, err := openRotatingFile(logfile, 10, 2)
+	a.Nil(err)
+	for _, v := range []string{"aaaaaa", "bbbbbb", "cccccc", "dddddd"} {
+		_, err = f.Write([]byte(v))
+		a.Nil(err)
+	}
+	for name, content := range map[string]string{"gs.log": "dddddd", "gs.log.1": "cccccc", "gs.log.2": "bbbbbb"} {
+		buf, err := ioutil.ReadFile(filepath.Join(dir, name))
+		a.Nil(err)
+		a.Equal(content, string(buf))
+	}
+	_, err = os.Stat(logfile + ".3")
+	a.True(os.IsNotExist(err))
+
+	level, err := parseLogLevel("WARNING")
+	a.Nil(err)
+	a.Equal(levelWarn, level)
+	_, err = parseLogLevel("verbose")
+	a.NotNil(err)
+}
+
+func TestDaemonReady(t *testing.T) {
+	a := assert.New(t)
+	r, w, err := os.Pipe()
+	a.Nil(err)
+	notifyDaemonReady(w, nil)
+	a.Nil(waitDaemonReady(r))
+	r.Close()
+
+	r, w, err = os.Pipe()
+	a.Nil(err)
+	notifyDaemonReady(w, errors.New("failed to listen\naddress already in use"))
+	err = waitDaemonReady(r)
+	a.NotNil(err)
+	a.Equal("failed to listen address already in use", err.Error())
+	r.Close()
+
+	// the background process died before reporting
+	r, w, err = os.Pipe()
+	a.Nil(err)
+	w.Close()
+	a.NotNil(waitDaemonReady(r))
+	r.Close()
+}
+
+func TestFormatLog(t *testing.T) {
+	a := assert.New(t)
+	now := time.Date(2019, 5, 1, 8, 0, 0, 0, time.UTC)
+	kv := []interface{}{"process_name", "web", "pid", 42, "status", pb.ProcessStatus_EXITED, "exit_code", int32(1), "err", errors.New("exit status 1")}
+	a.Equal(`INFO process exit process_name=web pid=42 status=EXITED exit_code=1 err=exit status 1`,
+		formatLog(pb.ConfigFile_TEXT, now, levelInfo, "a.go:1", "process exit", kv))
+	a.Equal(`{"time":"2019-05-01T08:00:00Z","level":"INFO","caller":"a.go:1","msg":"process exit","process_name":"web","pid":42,"status":"EXITED","exit_code":1,"err":"exit status 1"}`,
+		formatLog(pb.ConfigFile_JSON, now, levelInfo, "a.go:1", "process exit", kv))
+	a.Equal(`time=2019-05-01T08:00:00Z level=INFO caller=a.go:1 msg="process exit" process_name=web pid=42 status=EXITED exit_code=1 err="exit status 1"`,
+		formatLog(pb.ConfigFile_LOGFMT, now, levelInfo, "a.go:1", "process exit", kv))
+	a.Equal(`{"time":"2019-05-01T08:00:00Z","level":"WARN","caller":"","msg":"odd","key":null}`,
+		formatLog(pb.ConfigFile_JSON, now, levelWarn, "", "odd", []interface{}{"key"}))
+}
+
+func TestLogForward(t *testing.T) {
+	a := assert.New(t)
+	dir, err := ioutil.TempDir("", "gs")
+	a.Nil(err)
+	defer os.RemoveAll(dir)
+
+	var lines []string
+	w := newLineWriter(func(line []byte) { lines = append(lines, string(line)) })
+	w.Write([]byte("a\nb"))
+	w.Write([]byte("c\n\nd"))
+	a.Equal([]string{"a", "bc", ""}, lines)
+	w.flush()
+	a.Equal([]string{"a", "bc", "", "d"}, lines)
+
+	addr := filepath.Join(dir, "log.sock")
+	conn, err := net.ListenPacket("unixgram", addr)
+	a.Nil(err)
+	defer conn.Close()
+	recv := func() string {
+		buf := make([]byte, 4096)
+		conn.SetReadDeadline(time.Now().Add(time.Second * 3))
+		n, _, err := conn.ReadFrom(buf)
+		a.Nil(err)
+		return string(buf[:n])
+	}
+
+	p, err := newProcessInstances(&pb.ProcessSpec{
+		ProcessName: "fwd",
+		Command:     `sh -c "echo hello; echo oops >&2"`,
+		LogForward:  &pb.LogForward{Address: addr, Facility: "local0"},
+	})
+	a.Nil(err)
+	defer p.close()
+	p.events = newEventHub()
+	a.Nil(p.start(startByManual))
+	got := []string{recv(), recv()}
+	if !strings.HasSuffix(got[0], "hello") {
+		got[0], got[1] = got[1], got[0]
+	}
+	a.Regexp(`^<134>1 \S+ \S+ fwd \d+ - - hello$`, got[0])
+	a.Regexp(`^<131>1 \S+ \S+ fwd \d+ - - oops$`, got[1])
+
+	p, err = newProcessInstances(&pb.ProcessSpec{
+		ProcessName: "journal",
+		Command:     `echo hello`,
+		LogForward:  &pb.LogForward{Target: pb.LogForward_JOURNALD, Address: addr, Tag: "web"},
+	})
+	a.Nil(err)
+	p.events = newEventHub()
+	a.Nil(p.start(startByManual))
+	a.Regexp("^MESSAGE=hello\nPRIORITY=6\nSYSLOG_FACILITY=1\nSYSLOG_IDENTIFIER=web\nSYSLOG_PID=\\d+\n$", recv())
+	// lines after close are dropped instead of sent
+	<-p.exited
+	p.close()
+	p.close()
+	p.forwarder.queue(6, []byte("late"))
+	conn.SetReadDeadline(time.Now().Add(time.Millisecond * 200))
+	_, _, err = conn.ReadFrom(make([]byte, 4096))
+	a.NotNil(err)
+
+	_, err = newProcessInstances(&pb.ProcessSpec{ProcessName: "bad", Command: "true", LogForward: &pb.LogForward{StdoutPriority: "loud"}})
+	a.NotNil(err)
+}
+
+func TestPrefixWriter(t *testing.T) {
+	a := assert.New(t)
+	var out bytes.Buffer
+	web := newPrefixWriter(&out, "web")
+	db := newPrefixWriter(&out, "db ")
+	web.Write([]byte("GET /"))
+	db.Write([]byte("ready\nconn"))
+	web.Write([]byte("index\n"))
+	db.flush()
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	a.Len(lines, 3)
+	a.Regexp(`^\d{4}-\d\d-\d\d \d\d:\d\d:\d\d\.\d{3} db  \| ready$`, lines[0])
+	a.Regexp(`^\S+ \S+ web \| GET /index$`, lines[1])
+	a.Regexp(`^\S+ \S+ db  \| conn$`, lines[2])
+}
+
+func TestPty(t *testing.T) {
+	a := assert.New(t)
+	p, err := newProcessInstances(&pb.ProcessSpec{
+		ProcessName: "tty",
+		Command:     `sh -c 'test -t 0 && test -t 1 && echo istty; stty size; read l; stty size; echo done $l >&2'`,
+		Pty:         true,
+		Stdin:       true,
+		Startsecs:   0.1,
+	})
+	a.Nil(err)
+	a.NotNil(p.resize(0, 80))
+	a.Nil(p.start(startByManual))
+	waitOutput := func(s string) {
+		for i := 0; i < 30 && !strings.Contains(string(p.stdoutLog.tail(1024)), s); i++ {
+			time.Sleep(time.Millisecond * 100)
+		}
+		a.Contains(string(p.stdoutLog.tail(1024)), s)
+	}
+	waitOutput("istty\r\n24 80\r\n")
+	a.Nil(p.resize(50, 120))
+	a.Nil(p.writeStdin([]byte("x\n")))
+	<-p.exited
+	waitOutput("50 120\r\ndone x\r\n")
+	a.NotNil(p.writeStdin([]byte("x\n")))
+
+	_, err = newProcessInstances(&pb.ProcessSpec{ProcessName: "listener", Command: "cat", Pty: true, Events: []string{"TICK_5"}})
+	a.NotNil(err)
+	p, err = newProcessInstances(&pb.ProcessSpec{ProcessName: "nopty", Command: "true"})
+	a.Nil(err)
+	a.NotNil(p.resize(50, 120))
+}
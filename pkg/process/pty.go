@@ -0,0 +1,93 @@
+package process
+
+import (
+	"io"
+	"os"
+	"os/exec"
+	"time"
+
+	"github.com/pkg/errors"
+	pb "github.com/wangkechun/gosupervisor/pkg/proto"
+)
+
+const defaultPtyRows = 24
+const defaultPtyCols = 80
+
+// ptyDrainTimeout bounds reading the output left in the pty after the process exited,
+// a child of the process may keep the terminal open
+const ptyDrainTimeout = time.Second
+
+// ptyConsole is the pseudo-terminal of a run
+type ptyConsole struct {
+	master *os.File
+	done   chan struct{} // closed once the output is copied
+}
+
+// newPtyConsole sets up cmd to run on a new pseudo-terminal, the caller closes slave after cmd started
+func newPtyConsole(cmd *exec.Cmd, rows, cols uint16) (*ptyConsole, *os.File, error) {
+	master, slave, err := openPty()
+	if err != nil {
+		return nil, nil, err
+	}
+	if rows == 0 || cols == 0 {
+		rows, cols = defaultPtyRows, defaultPtyCols
+	}
+	if err := setPtySize(master, rows, cols); err != nil {
+		master.Close()
+		slave.Close()
+		return nil, nil, err
+	}
+	cmd.Stdin = slave
+	cmd.Stdout = slave
+	cmd.Stderr = slave
+	cmd.SysProcAttr = ptySysProcAttr()
+	return &ptyConsole{master: master, done: make(chan struct{})}, slave, nil
+}
+
+// copyOutput copies the output to out until the terminal is closed
+func (c *ptyConsole) copyOutput(out io.Writer) {
+	go func() {
+		// reading the master fails with EIO once the last slave is closed
+		io.Copy(out, c.master)
+		close(c.done)
+	}()
+}
+
+// wait reads the remaining output after the process exited and closes the terminal
+func (c *ptyConsole) wait() {
+	select {
+	case <-c.done:
+	case <-time.After(ptyDrainTimeout):
+	}
+	c.master.Close()
+	<-c.done
+}
+
+// resize sets the window size of the pty, it is kept for the following runs
+func (p *processInstances) resize(rows, cols uint16) error {
+	if !p.spec.Pty {
+		return errors.Errorf("process %v does not run on a pty", p.spec.ProcessName)
+	}
+	if rows == 0 || cols == 0 {
+		return errors.Errorf("bad window size %dx%d", rows, cols)
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.ptyRows, p.ptyCols = rows, cols
+	if p.pty == nil {
+		return nil
+	}
+	select {
+	case <-p.pty.done:
+		return nil
+	default:
+	}
+	return setPtySize(p.pty.master, rows, cols)
+}
+
+func validatePty(spec *pb.ProcessSpec) error {
+	if spec.Pty && len(spec.Events) > 0 {
+		return errors.Errorf("eventlistener %v can not run on a pty", spec.ProcessName)
+	}
+	return nil
+}
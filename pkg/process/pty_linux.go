@@ -0,0 +1,57 @@
+//go:build linux
+// +build linux
+
+package process
+
+import (
+	"os"
+	"strconv"
+	"syscall"
+	"unsafe"
+
+	"github.com/pkg/errors"
+)
+
+func ioctl(f *os.File, req uintptr, arg unsafe.Pointer) error {
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, f.Fd(), req, uintptr(arg))
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+// openPty opens a new pseudo-terminal through /dev/ptmx
+func openPty() (master, slave *os.File, err error) {
+	master, err = os.OpenFile("/dev/ptmx", os.O_RDWR|syscall.O_NOCTTY|syscall.O_CLOEXEC, 0)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "open /dev/ptmx")
+	}
+	var unlock int32
+	if err := ioctl(master, syscall.TIOCSPTLCK, unsafe.Pointer(&unlock)); err != nil {
+		master.Close()
+		return nil, nil, errors.Wrap(err, "unlock pty")
+	}
+	var n uint32
+	if err := ioctl(master, syscall.TIOCGPTN, unsafe.Pointer(&n)); err != nil {
+		master.Close()
+		return nil, nil, errors.Wrap(err, "get pty number")
+	}
+	name := "/dev/pts/" + strconv.Itoa(int(n))
+	slave, err = os.OpenFile(name, os.O_RDWR|syscall.O_NOCTTY|syscall.O_CLOEXEC, 0)
+	if err != nil {
+		master.Close()
+		return nil, nil, errors.Wrapf(err, "open %v", name)
+	}
+	return master, slave, nil
+}
+
+// setPtySize sets the window size, the foreground process group of the terminal gets SIGWINCH
+func setPtySize(master *os.File, rows, cols uint16) error {
+	ws := struct{ rows, cols, x, y uint16 }{rows, cols, 0, 0}
+	return errors.Wrap(ioctl(master, syscall.TIOCSWINSZ, unsafe.Pointer(&ws)), "set window size")
+}
+
+// ptySysProcAttr makes the pty on stdin the controlling terminal of a new session
+func ptySysProcAttr() *syscall.SysProcAttr {
+	return &syscall.SysProcAttr{Setsid: true, Setctty: true, Ctty: 0}
+}
@@ -0,0 +1,23 @@
+//go:build !linux
+// +build !linux
+
+package process
+
+import (
+	"os"
+	"syscall"
+
+	"github.com/pkg/errors"
+)
+
+func openPty() (master, slave *os.File, err error) {
+	return nil, nil, errors.New("pty is only supported on linux")
+}
+
+func setPtySize(master *os.File, rows, cols uint16) error {
+	return errors.New("pty is only supported on linux")
+}
+
+func ptySysProcAttr() *syscall.SysProcAttr {
+	return nil
+}
@@ -0,0 +1,56 @@
+package process
+
+import (
+	"context"
+	"path/filepath"
+	"time"
+
+	pb "github.com/wangkechun/gosupervisor/pkg/proto"
+)
+
+const defaultReadinessInterval = time.Millisecond * 500
+
+func newReadinessProbe(spec *pb.ProcessSpec) *probe {
+	r := spec.Readiness
+	file := r.File
+	if file != "" && !filepath.IsAbs(file) && spec.Directory != "" {
+		file = filepath.Join(spec.Directory, file)
+	}
+	return &probe{httpGet: r.HttpGet, tcpConnect: r.TcpConnect, file: file}
+}
+
+// waitReady returns a channel closed once the process is ready, nil if readiness is not configured
+func (p *processInstances) waitReady(exited chan struct{}, notify *notifySocket) <-chan struct{} {
+	r := p.spec.Readiness
+	if r == nil {
+		return nil
+	}
+	if r.Notify {
+		return notify.ready
+	}
+	interval := defaultReadinessInterval
+	if r.Interval > 0 {
+		interval = time.Duration(r.Interval * float32(time.Second))
+	}
+	pr := newReadinessProbe(p.spec)
+	ready := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			ctx, cancel := context.WithTimeout(context.Background(), defaultHealthCheckTimeout)
+			err := pr.check(ctx)
+			cancel()
+			if err == nil {
+				close(ready)
+				return
+			}
+			select {
+			case <-exited:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return ready
+}
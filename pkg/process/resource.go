@@ -0,0 +1,98 @@
+package process
+
+import (
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// clockTicksPerSecond is USER_HZ, 100 on every linux platform we run on
+const clockTicksPerSecond = 100
+
+type procStat struct {
+	state     string // R, S, Z...
+	ppid      int
+	cpuTicks  uint64 // utime + stime
+	rssBytes  int64
+	startTime uint64 // clock ticks after boot
+}
+
+// readProcStat reads /proc/[pid]/stat, it fails on platforms without procfs
+func readProcStat(pid int) (procStat, error) {
+	buf, err := ioutil.ReadFile("/proc/" + strconv.Itoa(pid) + "/stat")
+	if err != nil {
+		return procStat{}, errors.Wrap(err, "read proc stat")
+	}
+	// the command name may contain spaces and parentheses, fields start after the last ')'
+	s := string(buf)
+	i := strings.LastIndexByte(s, ')')
+	if i < 0 {
+		return procStat{}, errors.New("bad proc stat")
+	}
+	fields := strings.Fields(s[i+1:])
+	if len(fields) < 22 {
+		return procStat{}, errors.New("bad proc stat")
+	}
+	// fields[0] is the 3rd field "state" in proc(5)
+	utime, _ := strconv.ParseUint(fields[11], 10, 64)
+	stime, _ := strconv.ParseUint(fields[12], 10, 64)
+	startTime, _ := strconv.ParseUint(fields[19], 10, 64)
+	rss, _ := strconv.ParseInt(fields[21], 10, 64)
+	ppid, _ := strconv.Atoi(fields[1])
+	return procStat{state: fields[0], ppid: ppid, cpuTicks: utime + stime, rssBytes: rss * int64(os.Getpagesize()), startTime: startTime}, nil
+}
+
+// readProcCmdline reads the arguments of pid from /proc/[pid]/cmdline
+func readProcCmdline(pid int) ([]string, error) {
+	buf, err := ioutil.ReadFile("/proc/" + strconv.Itoa(pid) + "/cmdline")
+	if err != nil {
+		return nil, errors.Wrap(err, "read proc cmdline")
+	}
+	return strings.Split(strings.TrimSuffix(string(buf), "\x00"), "\x00"), nil
+}
+
+type resourceSample struct {
+	pid      int
+	cpuTicks uint64
+	at       time.Time
+}
+
+// sampleResource updates memory and cpu usage of the running process
+func (p *processInstances) sampleResource() {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	running := p.cmd != nil && p.cmd.Process != nil
+	if running {
+		// cmd.ProcessState is written by cmd.Wait without p.lock
+		select {
+		case <-p.exited:
+			running = false
+		default:
+		}
+	}
+	if !running {
+		p.status.MemoryUsage = 0
+		p.status.CpuPercent = 0
+		p.lastSample = resourceSample{}
+		return
+	}
+	pid := p.cmd.Process.Pid
+	stat, err := readProcStat(pid)
+	if err != nil {
+		return
+	}
+	now := time.Now()
+	p.status.MemoryUsage = int32(stat.rssBytes / 1024)
+	if p.lastSample.pid == pid {
+		elapsed := now.Sub(p.lastSample.at).Seconds()
+		if elapsed > 0 {
+			used := float64(stat.cpuTicks-p.lastSample.cpuTicks) / clockTicksPerSecond
+			p.status.CpuPercent = float32(used / elapsed * 100)
+		}
+	}
+	p.lastSample = resourceSample{pid: pid, cpuTicks: stat.cpuTicks, at: now}
+}
@@ -0,0 +1,120 @@
+package process
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	pb "github.com/wangkechun/gosupervisor/pkg/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const rollingCheckInterval = time.Millisecond * 200
+
+// rollingCommand runs a rolling restart in the background and waits for it,
+// so a dropped client connection does not leave it half done.
+func (s *serverInstance) rollingCommand(ctx context.Context, req *pb.CommandRequest) (*pb.CommandReply, error) {
+	if req.Command != pb.CommandRequest_RESTART {
+		return nil, status.Errorf(codes.InvalidArgument, "rolling only supports RESTART, got %v", req.Command)
+	}
+	names := req.ProcessNames
+	if len(names) == 0 && req.ProcessName != "" {
+		names = []string{req.ProcessName}
+	}
+	procs := make([]*processInstances, 0, len(names))
+	s.lock.RLock()
+	for _, name := range names {
+		p, ok := s.process[name]
+		if !ok {
+			s.lock.RUnlock()
+			return nil, status.Errorf(codes.NotFound, "process %v not found", name)
+		}
+		procs = append(procs, p)
+	}
+	s.lock.RUnlock()
+	batch := int(req.MaxUnavailable)
+	if batch <= 0 {
+		batch = 1
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- rollingRestart(procs, batch)
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			return nil, status.Error(codes.Aborted, err.Error())
+		}
+		return &pb.CommandReply{}, nil
+	case <-ctx.Done():
+		logInfo("rolling restart client gone, continue in background", "process_names", names)
+		return nil, ctx.Err()
+	}
+}
+
+// rollingRestart restarts procs batch at a time, a batch must be RUNNING and healthy
+// before the next one is restarted.
+func rollingRestart(procs []*processInstances, batch int) error {
+	for i := 0; i < len(procs); i += batch {
+		end := i + batch
+		if end > len(procs) {
+			end = len(procs)
+		}
+		group := procs[i:end]
+		errs := make(chan error, len(group))
+		for _, p := range group {
+			go func(p *processInstances) {
+				errs <- restartAndWait(p)
+			}(p)
+		}
+		var firstErr error
+		for range group {
+			if err := <-errs; err != nil && firstErr == nil {
+				firstErr = err
+			}
+		}
+		if firstErr != nil {
+			logWarn("rolling restart aborted", "err", firstErr)
+			return firstErr
+		}
+	}
+	logInfo("rolling restart done", "count", len(procs))
+	return nil
+}
+
+func restartAndWait(p *processInstances) error {
+	name := p.spec.ProcessName
+	logInfo("rolling restart", "process_name", name)
+	if err := p.manualRestart(); err != nil {
+		return errors.Wrapf(err, "restart %v", name)
+	}
+	return errors.Wrapf(p.waitHealthy(), "wait %v", name)
+}
+
+// waitHealthy waits until the process is RUNNING and, with a healthcheck, passed one check,
+// or until a oneshot process is COMPLETED
+func (p *processInstances) waitHealthy() error {
+	ticker := time.NewTicker(rollingCheckInterval)
+	defer ticker.Stop()
+	for range ticker.C {
+		p.lock.RLock()
+		st := p.status.Status
+		desc := p.status.ProcessDesc
+		healthy := p.healthy || p.spec.Healthcheck == nil
+		p.lock.RUnlock()
+		switch st {
+		case pb.ProcessStatus_STARTING:
+		case pb.ProcessStatus_RUNNING:
+			if healthy {
+				return nil
+			}
+		case pb.ProcessStatus_COMPLETED:
+			return nil
+		default:
+			return errors.Errorf("process %v: %v", st, desc)
+		}
+	}
+	return nil
+}
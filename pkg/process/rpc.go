@@ -2,9 +2,14 @@ package process
 
 import (
 	"context"
+	"fmt"
+	"io"
 	"io/ioutil"
-	"log"
+	"math"
 	"net"
+	"net/http"
+	"os"
+	"path/filepath"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/pkg/errors"
@@ -24,6 +29,9 @@ func (s *serverInstance) List(ctx context.Context, req *pb.ListRequest) (resp *p
 }
 
 func (s *serverInstance) Command(ctx context.Context, req *pb.CommandRequest) (resp *pb.CommandReply, err error) {
+	if req.Rolling {
+		return s.rollingCommand(ctx, req)
+	}
 	name := req.ProcessName
 	cmd := req.Command
 	s.lock.Lock()
@@ -37,11 +45,7 @@ func (s *serverInstance) Command(ctx context.Context, req *pb.CommandRequest) (r
 		err := p.kill()
 		return &pb.CommandReply{}, err
 	case pb.CommandRequest_RESTART:
-		err = p.stop()
-		if err != nil {
-			return &pb.CommandReply{}, err
-		}
-		err = p.start(startByManual)
+		err = p.manualRestart()
 		return &pb.CommandReply{}, err
 	case pb.CommandRequest_START:
 		err = p.start(startByManual)
@@ -49,11 +53,118 @@ func (s *serverInstance) Command(ctx context.Context, req *pb.CommandRequest) (r
 	case pb.CommandRequest_STOP:
 		err = p.stop()
 		return &pb.CommandReply{}, err
+	case pb.CommandRequest_RELOAD:
+		sig, err := reloadSignal(p.spec)
+		if err != nil {
+			return nil, err
+		}
+		return &pb.CommandReply{}, p.signal(sig)
+	case pb.CommandRequest_SIGNAL:
+		sig, err := parseSignal(req.Signal)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return &pb.CommandReply{}, p.signal(sig)
 	}
 	return nil, status.Error(codes.Unimplemented, "Unimplemented")
 }
 
-func RunServer(cfgPath string) error {
+func (s *serverInstance) Watch(req *pb.WatchRequest, stream pb.GoSupervisor_WatchServer) error {
+	s.lock.RLock()
+	for _, name := range req.ProcessName {
+		if _, ok := s.process[name]; !ok {
+			s.lock.RUnlock()
+			return status.Errorf(codes.NotFound, "process %v not found", name)
+		}
+	}
+	s.lock.RUnlock()
+	ch := s.events.subscribe()
+	defer s.events.unsubscribe(ch)
+	match := watchFilter(req.ProcessName)
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case ev := <-ch:
+			if !match(ev) {
+				continue
+			}
+			if err := stream.Send(ev); err != nil {
+				return errors.Wrap(err, "send event")
+			}
+		}
+	}
+}
+
+func (s *serverInstance) Attach(stream pb.GoSupervisor_AttachServer) error {
+	req, err := stream.Recv()
+	if err == io.EOF {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrap(err, "recv attach request")
+	}
+	s.lock.RLock()
+	p, ok := s.process[req.ProcessName]
+	s.lock.RUnlock()
+	if !ok {
+		return status.Errorf(codes.NotFound, "process %v not found", req.ProcessName)
+	}
+	ch := p.output.subscribe()
+	defer p.output.unsubscribe(ch)
+	inputErr := make(chan error, 1)
+	go func(req *pb.AttachRequest) {
+		for {
+			if len(req.Input) > 0 {
+				if err := p.writeStdin(req.Input); err != nil {
+					inputErr <- status.Error(codes.FailedPrecondition, err.Error())
+					return
+				}
+			}
+			var err error
+			req, err = stream.Recv()
+			if err == io.EOF {
+				// detached, the process keeps running
+				inputErr <- nil
+				return
+			}
+			if err != nil {
+				inputErr <- errors.Wrap(err, "recv input")
+				return
+			}
+		}
+	}(req)
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case err := <-inputErr:
+			return err
+		case reply := <-ch:
+			if err := stream.Send(reply); err != nil {
+				return errors.Wrap(err, "send output")
+			}
+		}
+	}
+}
+
+func (s *serverInstance) Resize(ctx context.Context, req *pb.ResizeRequest) (*pb.ResizeReply, error) {
+	s.lock.RLock()
+	p, ok := s.process[req.ProcessName]
+	s.lock.RUnlock()
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "process %v not found", req.ProcessName)
+	}
+	if req.Rows > math.MaxUint16 || req.Cols > math.MaxUint16 {
+		return nil, status.Errorf(codes.InvalidArgument, "bad window size %dx%d", req.Rows, req.Cols)
+	}
+	if err := p.resize(uint16(req.Rows), uint16(req.Cols)); err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return &pb.ResizeReply{}, nil
+}
+
+func RunServer(cfgPath string, opts DaemonOptions) (rerr error) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 	buf, err := ioutil.ReadFile(cfgPath)
@@ -65,20 +176,98 @@ func RunServer(cfgPath string) error {
 	if err != nil {
 		return errors.Wrap(err, "parse config failed")
 	}
-	s := &serverInstance{config: &p, process: make(map[string]*processInstances)}
+	// ready is set in the background process, the starting process waits for the listeners or an error
+	var ready *os.File
+	if opts.Daemonize {
+		pid, f, err := daemonize()
+		if err != nil {
+			return err
+		}
+		if pid != 0 {
+			fmt.Printf("gosupervisor daemon started, pid:%d\n", pid)
+			return nil
+		}
+		ready = f
+		defer func() {
+			notifyDaemonReady(ready, rerr)
+		}()
+	}
+	if opts.Pidfile != "" {
+		opts.Pidfile, err = filepath.Abs(opts.Pidfile)
+		if err != nil {
+			return errors.Wrap(err, "pidfile path")
+		}
+	}
+	err = applyDaemonOptions(opts)
+	if err != nil {
+		return err
+	}
+	setLogFormat(p.LogFormat)
+	s := newServerInstance(&p)
+	exit := os.Exit
+	if opts.Pidfile != "" {
+		err = writePidfile(opts.Pidfile)
+		if err != nil {
+			return err
+		}
+		defer os.Remove(opts.Pidfile)
+		exit = func(code int) {
+			os.Remove(opts.Pidfile)
+			os.Exit(code)
+		}
+		if !p.Init {
+			go s.exitOnSignal(exit)
+		}
+	}
 	err = s.initLoad()
 	if err != nil {
 		return errors.Wrap(err, "load config failed")
 	}
+	err = s.validateInit()
+	if err != nil {
+		return errors.Wrap(err, "load config failed")
+	}
+	if p.Init {
+		s.initSubreaper()
+		go s.initRunInit(ctx, s.events.subscribe(), exit)
+	}
+	err = s.initRestoreState()
+	if err != nil {
+		return errors.Wrap(err, "restore state failed")
+	}
+	err = s.initBindSockets()
+	if err != nil {
+		return errors.Wrap(err, "bind sockets failed")
+	}
+	// subscribe before the processes start, so their first transitions are not missed
+	go s.initRunStateSaver(ctx, s.events.subscribe())
+	go s.initRunEventListener(ctx, s.events.subscribe())
+	go s.initRunNotifier(ctx, s.events.subscribe())
 	s.initStartAll()
 	go s.initRunMonitor(ctx)
+	go s.initRunScheduler(ctx)
 	lis, err := net.Listen("tcp", p.RpcAddr)
 	if err != nil {
 		return errors.Wrapf(err, "failed to listen, addr %v", p.RpcAddr)
 	}
+	if p.HttpAddr != "" {
+		httpLis, err := net.Listen("tcp", p.HttpAddr)
+		if err != nil {
+			return errors.Wrapf(err, "failed to listen, addr %v", p.HttpAddr)
+		}
+		logInfo("gosupervisor http listen", "addr", p.HttpAddr)
+		go func() {
+			err := http.Serve(httpLis, s.httpHandler())
+			if err != nil {
+				logError("http serve failed", "err", err)
+			}
+		}()
+	}
 	svr := grpc.NewServer()
 	pb.RegisterGoSupervisorServer(svr, s)
-	log.Println("gosupervisor listen addr:", p.RpcAddr)
+	logInfo("gosupervisor listen", "addr", p.RpcAddr)
+	notifyDaemonReady(ready, nil)
+	ready = nil
 	if err := svr.Serve(lis); err != nil {
 		return errors.Wrap(err, "failed to serve")
 	}
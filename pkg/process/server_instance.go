@@ -2,7 +2,8 @@ package process
 
 import (
 	"context"
-	"log"
+	"fmt"
+	"os"
 	"sync"
 	"time"
 
@@ -17,6 +18,13 @@ type serverInstance struct {
 	config  *pb.ConfigFile
 	process map[string]*processInstances
 	lock    sync.RWMutex
+	events  *eventHub
+	// shutdown is closed once stopAll stops the processes for the daemon exiting
+	shutdown *shutdownGate
+}
+
+func newServerInstance(config *pb.ConfigFile) *serverInstance {
+	return &serverInstance{config: config, process: make(map[string]*processInstances), events: newEventHub(), shutdown: &shutdownGate{}}
 }
 
 func (s *serverInstance) initLoad() error {
@@ -27,33 +35,112 @@ func (s *serverInstance) initLoad() error {
 		name := v.ProcessName
 		_, ok := s.process[name]
 		if ok {
+			s.closeAll()
 			return errors.Errorf("find duplicate process %v", name)
 		}
 		p, err := newProcessInstances(v)
 		if err != nil {
+			s.closeAll()
 			return errors.Wrap(err, "newProcessInstances")
 		}
+		p.events = s.events
+		p.startGate = s.shutdown
 		s.process[name] = p
 	}
+	if s.config.StateFile != "" && outputFifoSupported {
+		// output outlives the daemon, so the next daemon can adopt the running processes
+		dir := s.config.StateFile + ".output"
+		if err := os.MkdirAll(dir, 0700); err != nil {
+			s.closeAll()
+			return errors.Wrap(err, "create output dir")
+		}
+		for _, p := range s.process {
+			p.outputDir = dir
+		}
+	}
+	if s.config.PrefixOutput {
+		width := 0
+		for name := range s.process {
+			if len(name) > width {
+				width = len(name)
+			}
+		}
+		for name, p := range s.process {
+			prefix := fmt.Sprintf("%-*s", width, name)
+			p.stdout = newPrefixWriter(os.Stdout, prefix)
+			p.stderr = newPrefixWriter(os.Stderr, prefix)
+		}
+	}
+	if err := s.resolveDependencies(); err != nil {
+		s.closeAll()
+		return err
+	}
+	return nil
+}
+
+// closeAll discards the loaded process instances when the config fails to load
+func (s *serverInstance) closeAll() {
+	for name, p := range s.process {
+		p.close()
+		delete(s.process, name)
+	}
+}
+
+// initBindSockets binds the listen sockets of the processes which were not adopted,
+// an adopted process still holds the sockets it got from the previous daemon
+func (s *serverInstance) initBindSockets() error {
+	for _, p := range s.process {
+		if p.alive() {
+			continue
+		}
+		sockets, err := bindSockets(p.spec)
+		if err != nil {
+			return err
+		}
+		p.lock.Lock()
+		p.sockets = sockets
+		p.lock.Unlock()
+	}
 	return nil
 }
 
 func (s *serverInstance) initStartAll() {
 	for _, v := range s.process {
+		if v.alive() {
+			// adopted from the previous daemon
+			continue
+		}
 		go func(v *processInstances) {
+			v.lock.RLock()
+			autostart := v.autostart()
+			v.lock.RUnlock()
+			if len(v.deps) > 0 && autostart {
+				if err := v.waitDependencies(); err != nil {
+					logWarn("start process failed", "process_name", v.spec.ProcessName, "err", err)
+					return
+				}
+			}
 			err := v.start(startByAuto)
 			if err != nil {
-				log.Printf("start process:%v, err:%v", v.spec.ProcessName, err)
+				logWarn("start process failed", "process_name", v.spec.ProcessName, "err", err)
 			}
 		}(v)
 	}
 }
 
 func (s *serverInstance) monitor() {
+	if s.shutdown.closed() {
+		return
+	}
 	s.lock.RLock()
 	process := make([]*processInstances, 0)
 	for _, v := range s.process {
+		v.sampleResource()
 		status := v.readStatus()
+		if v.spec.Schedule != "" {
+			// scheduled processes are started by the scheduler only
+			continue
+		}
 		if status.Status.Status == pb.ProcessStatus_BACKOFF || status.Status.Status == pb.ProcessStatus_EXITED {
 			process = append(process, v)
 		}
@@ -61,10 +148,10 @@ func (s *serverInstance) monitor() {
 	s.lock.RUnlock()
 
 	for _, process := range process {
-		log.Println("monitor try start process", process.spec.ProcessName)
+		logDebug("monitor try start process", "process_name", process.spec.ProcessName)
 		err := process.start(startByMonitor)
 		if err != nil {
-			log.Println("monitor try start process failed", process.spec.ProcessName, err)
+			logWarn("monitor try start process failed", "process_name", process.spec.ProcessName, "err", err)
 		}
 	}
 }
@@ -0,0 +1,64 @@
+package process
+
+import (
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/pkg/errors"
+	pb "github.com/wangkechun/gosupervisor/pkg/proto"
+)
+
+const defaultReloadSignal = "HUP"
+
+// signalNames are the signals defined on every platform, the others are in unixSignalNames
+var signalNames = map[string]syscall.Signal{
+	"HUP":  syscall.SIGHUP,
+	"INT":  syscall.SIGINT,
+	"QUIT": syscall.SIGQUIT,
+	"KILL": syscall.SIGKILL,
+	"TERM": syscall.SIGTERM,
+	"ALRM": syscall.SIGALRM,
+}
+
+// parseSignal parses a signal name like HUP, SIGHUP or a number like 1
+func parseSignal(name string) (syscall.Signal, error) {
+	if n, err := strconv.Atoi(name); err == nil && n > 0 {
+		return syscall.Signal(n), nil
+	}
+	key := strings.TrimPrefix(strings.ToUpper(name), "SIG")
+	sig, ok := signalNames[key]
+	if !ok {
+		sig, ok = unixSignalNames[key]
+	}
+	if !ok {
+		return 0, errors.Errorf("unknown signal %q", name)
+	}
+	return sig, nil
+}
+
+func reloadSignal(spec *pb.ProcessSpec) (syscall.Signal, error) {
+	name := spec.Reloadsignal
+	if name == "" {
+		name = defaultReloadSignal
+	}
+	return parseSignal(name)
+}
+
+// signal sends sig to the running process without changing its status
+func (p *processInstances) signal(sig syscall.Signal) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.cmd == nil {
+		return errors.New("process not exists")
+	}
+	select {
+	case <-p.exited:
+		return errors.New("process not running")
+	default:
+	}
+	logDebug("signal process", "process_name", p.spec.ProcessName, "signal", sig)
+	err := p.cmd.Process.Signal(sig)
+	p.signalMainPid(sig)
+	return errors.Wrap(err, "signal process")
+}
@@ -0,0 +1,15 @@
+//go:build !windows
+// +build !windows
+
+package process
+
+import "syscall"
+
+var unixSignalNames = map[string]syscall.Signal{
+	"USR1":  syscall.SIGUSR1,
+	"USR2":  syscall.SIGUSR2,
+	"CONT":  syscall.SIGCONT,
+	"STOP":  syscall.SIGSTOP,
+	"TSTP":  syscall.SIGTSTP,
+	"WINCH": syscall.SIGWINCH,
+}
@@ -0,0 +1,22 @@
+//go:build !windows
+// +build !windows
+
+package process
+
+import (
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	pb "github.com/wangkechun/gosupervisor/pkg/proto"
+)
+
+func TestParseUnixSignal(t *testing.T) {
+	a := assert.New(t)
+	sig, err := reloadSignal(&pb.ProcessSpec{Reloadsignal: "USR2"})
+	a.Nil(err)
+	a.Equal(syscall.SIGUSR2, sig)
+	sig, err = parseSignal("SIGWINCH")
+	a.Nil(err)
+	a.Equal(syscall.SIGWINCH, sig)
+}
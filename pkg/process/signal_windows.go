@@ -0,0 +1,8 @@
+//go:build windows
+// +build windows
+
+package process
+
+import "syscall"
+
+var unixSignalNames = map[string]syscall.Signal{}
@@ -0,0 +1,105 @@
+package process
+
+import (
+	"net"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	pb "github.com/wangkechun/gosupervisor/pkg/proto"
+)
+
+// listenFdsStart is the first fd of passed sockets, like systemd SD_LISTEN_FDS_START
+const listenFdsStart = 3
+
+// listenPidScript sets LISTEN_PID to the pid of the exec'd command, which is unknown before fork
+const listenPidScript = `LISTEN_PID=$$; export LISTEN_PID; exec "$@"`
+
+// boundSocket is a listening socket kept open by the daemon for the whole lifetime of a process
+type boundSocket struct {
+	name     string
+	listener net.Listener
+	file     *os.File
+}
+
+func bindSocket(ls *pb.ListenSocket) (*boundSocket, error) {
+	network := ls.Network
+	if network == "" {
+		network = "tcp"
+	}
+	switch network {
+	case "tcp", "tcp4", "tcp6", "unix":
+	default:
+		return nil, errors.Errorf("unsupported listen network %v", network)
+	}
+	if network == "unix" {
+		os.Remove(ls.Address)
+	}
+	ln, err := net.Listen(network, ls.Address)
+	if err != nil {
+		return nil, errors.Wrap(err, "listen")
+	}
+	var file *os.File
+	switch l := ln.(type) {
+	case *net.TCPListener:
+		file, err = l.File()
+	case *net.UnixListener:
+		file, err = l.File()
+	}
+	if err != nil {
+		ln.Close()
+		return nil, errors.Wrap(err, "listener file")
+	}
+	name := ls.Name
+	if name == "" {
+		name = ls.Address
+	}
+	return &boundSocket{name: name, listener: ln, file: file}, nil
+}
+
+// bindSockets binds all listen sockets of spec
+func bindSockets(spec *pb.ProcessSpec) ([]*boundSocket, error) {
+	sockets := make([]*boundSocket, 0, len(spec.Listen))
+	for _, ls := range spec.Listen {
+		s, err := bindSocket(ls)
+		if err != nil {
+			for _, v := range sockets {
+				v.close()
+			}
+			return nil, errors.Wrapf(err, "bind %v %v, name:%v", ls.Network, ls.Address, spec.ProcessName)
+		}
+		sockets = append(sockets, s)
+	}
+	return sockets, nil
+}
+
+func (s *boundSocket) close() {
+	s.file.Close()
+	s.listener.Close()
+}
+
+// listenEnv returns LISTEN_FDS and LISTEN_FDNAMES for sockets, LISTEN_PID is set by listenPidScript
+func listenEnv(sockets []*boundSocket) []string {
+	names := make([]string, 0, len(sockets))
+	for _, s := range sockets {
+		names = append(names, s.name)
+	}
+	return []string{
+		"LISTEN_FDS=" + strconv.Itoa(len(sockets)),
+		"LISTEN_FDNAMES=" + strings.Join(names, ":"),
+	}
+}
+
+// listenArgs wraps args with a shell which sets LISTEN_PID before exec
+func listenArgs(args []string) []string {
+	return append([]string{"sh", "-c", listenPidScript, "sh"}, args...)
+}
+
+func listenFiles(sockets []*boundSocket) []*os.File {
+	files := make([]*os.File, 0, len(sockets))
+	for _, s := range sockets {
+		files = append(files, s.file)
+	}
+	return files
+}
@@ -0,0 +1,143 @@
+package process
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/pkg/errors"
+	pb "github.com/wangkechun/gosupervisor/pkg/proto"
+)
+
+// saveState returns what is persisted across daemon restarts
+func (p *processInstances) saveState() *pb.SavedProcess {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	r := &pb.SavedProcess{
+		ProcessName:     p.spec.ProcessName,
+		Desired:         p.desired,
+		RestartedCount:  p.status.RestartedCount,
+		LastExitCode:    p.lastExitCode,
+		LastRunResult:   p.status.LastRunResult,
+		Status:          p.status.Status,
+		ProcessDesc:     p.status.ProcessDesc,
+		LastStartedTime: p.status.LastStartedTime,
+	}
+	p.saveIdentity(r)
+	if !p.stoppedAt.IsZero() {
+		r.LastStoppedTime = p.stoppedAt.Unix()
+	}
+	return r
+}
+
+// restoreState must be called before the process is started
+func (p *processInstances) restoreState(st *pb.SavedProcess) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.desired = st.Desired
+	p.status.RestartedCount = st.RestartedCount
+	p.status.LastRunResult = st.LastRunResult
+	p.lastExitCode = st.LastExitCode
+	if st.LastStoppedTime != 0 {
+		p.stoppedAt = time.Unix(st.LastStoppedTime, 0)
+	}
+	switch st.Status {
+	case pb.ProcessStatus_STOPPED, pb.ProcessStatus_EXITED, pb.ProcessStatus_FATAL, pb.ProcessStatus_COMPLETED:
+		p.status.Status = st.Status
+		p.status.ProcessDesc = st.ProcessDesc
+	}
+}
+
+func (s *serverInstance) saveState() error {
+	s.lock.RLock()
+	state := pb.SavedState{}
+	for _, v := range s.config.Process {
+		state.Process = append(state.Process, s.process[v.ProcessName].saveState())
+	}
+	s.lock.RUnlock()
+	m := jsonpb.Marshaler{Indent: "  "}
+	var buf bytes.Buffer
+	if err := m.Marshal(&buf, &state); err != nil {
+		return errors.Wrap(err, "marshal state")
+	}
+	path := s.config.StateFile
+	tmp, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		return errors.Wrap(err, "create state file")
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(buf.Bytes()); err != nil {
+		tmp.Close()
+		return errors.Wrap(err, "write state file")
+	}
+	if err := tmp.Close(); err != nil {
+		return errors.Wrap(err, "close state file")
+	}
+	return errors.Wrap(os.Rename(tmp.Name(), path), "rename state file")
+}
+
+// initRestoreState restores processes from the state file, a missing file is not an error
+func (s *serverInstance) initRestoreState() error {
+	if s.config.StateFile == "" {
+		return nil
+	}
+	f, err := os.Open(s.config.StateFile)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrap(err, "open state file")
+	}
+	defer f.Close()
+	var state pb.SavedState
+	u := jsonpb.Unmarshaler{AllowUnknownFields: true}
+	if err := u.Unmarshal(f, &state); err != nil {
+		return errors.Wrap(err, "parse state file")
+	}
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	for _, v := range state.Process {
+		p, ok := s.process[v.ProcessName]
+		if !ok {
+			logWarn("drop state of removed process", "process_name", v.ProcessName)
+			continue
+		}
+		p.restoreState(v)
+		p.adopt(v)
+	}
+	logInfo("state restored", "state_file", s.config.StateFile, "count", len(state.Process))
+	return nil
+}
+
+// initRunStateSaver writes the state file after every process state transition
+func (s *serverInstance) initRunStateSaver(ctx context.Context, ch chan *pb.ProcessEvent) {
+	defer s.events.unsubscribe(ch)
+	if s.config.StateFile == "" {
+		return
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ch:
+		}
+		// one write for a burst of transitions
+		for drained := false; !drained; {
+			select {
+			case <-ch:
+			default:
+				drained = true
+			}
+		}
+		if s.shutdown.closed() {
+			continue
+		}
+		if err := s.saveState(); err != nil {
+			logError("save state failed", "err", err)
+		}
+	}
+}
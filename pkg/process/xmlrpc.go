@@ -0,0 +1,508 @@
+package process
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"mime"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	pb "github.com/wangkechun/gosupervisor/pkg/proto"
+)
+
+// supervisord xmlrpc api version we are compatible with
+const supervisorAPIVersion = "3.0"
+
+// supervisord fault codes
+const (
+	faultUnknownMethod  = 1
+	faultIncorrectParam = 2
+	faultBadArguments   = 3
+	faultBadName        = 10
+	faultBadSignal      = 11
+	faultFailed         = 30
+	faultNoFile         = 40
+	faultSpawnError     = 50
+	faultAlreadyStarted = 60
+	faultNotRunning     = 70
+)
+
+// supervisord process state codes
+var supervisorStateCodes = map[pb.ProcessStatus_Status]int{
+	pb.ProcessStatus_INIT:     0,
+	pb.ProcessStatus_STOPPED:  0,
+	pb.ProcessStatus_STARTING: 10,
+	pb.ProcessStatus_RUNNING:  20,
+	// supervisord has no UNHEALTHY, the process is still running
+	pb.ProcessStatus_UNHEALTHY: 20,
+	pb.ProcessStatus_BACKOFF:   30,
+	pb.ProcessStatus_STOPPING:  40,
+	pb.ProcessStatus_EXITED:    100,
+	// a completed oneshot process exited as expected
+	pb.ProcessStatus_COMPLETED: 100,
+	pb.ProcessStatus_FATAL:     200,
+}
+
+const xmlrpcStartPollInterval = time.Millisecond * 100
+
+type xmlrpcFault struct {
+	code int
+	msg  string
+}
+
+func (f *xmlrpcFault) Error() string {
+	return fmt.Sprintf("%d: %s", f.code, f.msg)
+}
+
+func newFault(code int, format string, args ...interface{}) *xmlrpcFault {
+	return &xmlrpcFault{code: code, msg: fmt.Sprintf(format, args...)}
+}
+
+type xmlrpcValue struct {
+	String  *string        `xml:"string"`
+	Int     *int64         `xml:"int"`
+	I4      *int64         `xml:"i4"`
+	Boolean *int           `xml:"boolean"`
+	Double  *float64       `xml:"double"`
+	Array   *xmlrpcArray   `xml:"array"`
+	Struct  []xmlrpcMember `xml:"struct>member"`
+	Text    string         `xml:",chardata"`
+}
+
+type xmlrpcArray struct {
+	Data []xmlrpcValue `xml:"data>value"`
+}
+
+type xmlrpcMember struct {
+	Name  string      `xml:"name"`
+	Value xmlrpcValue `xml:"value"`
+}
+
+type xmlrpcCall struct {
+	MethodName string        `xml:"methodName"`
+	Params     []xmlrpcValue `xml:"params>param>value"`
+}
+
+// decode converts a value to string, int64, bool, float64, []interface{} or map[string]interface{}
+func (v *xmlrpcValue) decode() interface{} {
+	switch {
+	case v.String != nil:
+		return *v.String
+	case v.Int != nil:
+		return *v.Int
+	case v.I4 != nil:
+		return *v.I4
+	case v.Boolean != nil:
+		return *v.Boolean != 0
+	case v.Double != nil:
+		return *v.Double
+	case v.Array != nil:
+		r := make([]interface{}, 0, len(v.Array.Data))
+		for i := range v.Array.Data {
+			r = append(r, v.Array.Data[i].decode())
+		}
+		return r
+	case v.Struct != nil:
+		r := make(map[string]interface{}, len(v.Struct))
+		for i := range v.Struct {
+			r[v.Struct[i].Name] = v.Struct[i].Value.decode()
+		}
+		return r
+	}
+	// a value without type is a string
+	return v.Text
+}
+
+func encodeXMLRPCValue(buf *bytes.Buffer, v interface{}) {
+	buf.WriteString("<value>")
+	switch v := v.(type) {
+	case string:
+		buf.WriteString("<string>")
+		xml.EscapeText(buf, []byte(v))
+		buf.WriteString("</string>")
+	case int:
+		fmt.Fprintf(buf, "<int>%d</int>", v)
+	case int32:
+		fmt.Fprintf(buf, "<int>%d</int>", v)
+	case int64:
+		fmt.Fprintf(buf, "<int>%d</int>", v)
+	case bool:
+		if v {
+			buf.WriteString("<boolean>1</boolean>")
+		} else {
+			buf.WriteString("<boolean>0</boolean>")
+		}
+	case float64:
+		fmt.Fprintf(buf, "<double>%s</double>", strconv.FormatFloat(v, 'f', -1, 64))
+	case []interface{}:
+		buf.WriteString("<array><data>")
+		for _, e := range v {
+			encodeXMLRPCValue(buf, e)
+		}
+		buf.WriteString("</data></array>")
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		buf.WriteString("<struct>")
+		for _, k := range keys {
+			buf.WriteString("<member><name>")
+			xml.EscapeText(buf, []byte(k))
+			buf.WriteString("</name>")
+			encodeXMLRPCValue(buf, v[k])
+			buf.WriteString("</member>")
+		}
+		buf.WriteString("</struct>")
+	default:
+		panic(fmt.Sprintf("unsupported xmlrpc type %T", v))
+	}
+	buf.WriteString("</value>")
+}
+
+func encodeXMLRPCResponse(result interface{}, err error) []byte {
+	var buf bytes.Buffer
+	buf.WriteString(`<?xml version="1.0"?>` + "\n<methodResponse>")
+	if err != nil {
+		fault, ok := err.(*xmlrpcFault)
+		if !ok {
+			fault = newFault(faultFailed, "%v", err)
+		}
+		buf.WriteString("<fault>")
+		encodeXMLRPCValue(&buf, map[string]interface{}{"faultCode": fault.code, "faultString": fault.msg})
+		buf.WriteString("</fault>")
+	} else {
+		buf.WriteString("<params><param>")
+		encodeXMLRPCValue(&buf, result)
+		buf.WriteString("</param></params>")
+	}
+	buf.WriteString("</methodResponse>\n")
+	return buf.Bytes()
+}
+
+type xmlrpcMethod func(ctx context.Context, params []interface{}) (interface{}, error)
+
+// xmlrpcMethods implements the supervisord "supervisor" xmlrpc namespace on top of the grpc api
+func (s *serverInstance) xmlrpcMethods() map[string]xmlrpcMethod {
+	m := map[string]xmlrpcMethod{
+		"supervisor.getAPIVersion": func(ctx context.Context, params []interface{}) (interface{}, error) {
+			return supervisorAPIVersion, nil
+		},
+		"supervisor.getSupervisorVersion": func(ctx context.Context, params []interface{}) (interface{}, error) {
+			return ServiceVersion, nil
+		},
+		"supervisor.getIdentification": func(ctx context.Context, params []interface{}) (interface{}, error) {
+			return "supervisor", nil
+		},
+		"supervisor.getState": func(ctx context.Context, params []interface{}) (interface{}, error) {
+			return map[string]interface{}{"statecode": 1, "statename": "RUNNING"}, nil
+		},
+		"supervisor.getPID": func(ctx context.Context, params []interface{}) (interface{}, error) {
+			return os.Getpid(), nil
+		},
+		"supervisor.getAllProcessInfo": func(ctx context.Context, params []interface{}) (interface{}, error) {
+			list := s.readStatusAll()
+			r := make([]interface{}, 0, len(list.Process))
+			for _, v := range list.Process {
+				r = append(r, s.xmlrpcProcessInfo(v))
+			}
+			return r, nil
+		},
+		"supervisor.getProcessInfo": func(ctx context.Context, params []interface{}) (interface{}, error) {
+			p, err := s.xmlrpcProcess(params)
+			if err != nil {
+				return nil, err
+			}
+			st := p.readStatus()
+			return s.xmlrpcProcessInfo(&st), nil
+		},
+		"supervisor.startProcess": s.xmlrpcStartProcess,
+		"supervisor.stopProcess": func(ctx context.Context, params []interface{}) (interface{}, error) {
+			p, err := s.xmlrpcProcess(params)
+			if err != nil {
+				return nil, err
+			}
+			switch p.readStatus().Status.Status {
+			case pb.ProcessStatus_STARTING, pb.ProcessStatus_RUNNING, pb.ProcessStatus_UNHEALTHY, pb.ProcessStatus_BACKOFF:
+			default:
+				return nil, newFault(faultNotRunning, "NOT_RUNNING: %v", p.spec.ProcessName)
+			}
+			_, err = s.Command(ctx, &pb.CommandRequest{Command: pb.CommandRequest_STOP, ProcessName: p.spec.ProcessName})
+			if err != nil {
+				return nil, newFault(faultFailed, "FAILED: %v", err)
+			}
+			return true, nil
+		},
+		"supervisor.signalProcess": func(ctx context.Context, params []interface{}) (interface{}, error) {
+			p, err := s.xmlrpcProcess(params)
+			if err != nil {
+				return nil, err
+			}
+			if len(params) < 2 {
+				return nil, newFault(faultIncorrectParam, "INCORRECT_PARAMETERS")
+			}
+			sig, err := parseSignal(fmt.Sprint(params[1]))
+			if err != nil {
+				return nil, newFault(faultBadSignal, "BAD_SIGNAL: %v", params[1])
+			}
+			if err := p.signal(sig); err != nil {
+				return nil, newFault(faultNotRunning, "NOT_RUNNING: %v", p.spec.ProcessName)
+			}
+			return true, nil
+		},
+		"supervisor.sendProcessStdin": func(ctx context.Context, params []interface{}) (interface{}, error) {
+			p, err := s.xmlrpcProcess(params)
+			if err != nil {
+				return nil, err
+			}
+			if len(params) < 2 {
+				return nil, newFault(faultIncorrectParam, "INCORRECT_PARAMETERS")
+			}
+			chars, ok := params[1].(string)
+			if !ok {
+				return nil, newFault(faultIncorrectParam, "INCORRECT_PARAMETERS")
+			}
+			if !p.alive() {
+				return nil, newFault(faultNotRunning, "NOT_RUNNING: %v", p.spec.ProcessName)
+			}
+			if err := p.writeStdin([]byte(chars)); err != nil {
+				return nil, newFault(faultNoFile, "NO_FILE: %v", err)
+			}
+			return true, nil
+		},
+		"supervisor.readProcessStdoutLog": func(ctx context.Context, params []interface{}) (interface{}, error) {
+			return s.xmlrpcReadLog(params, false)
+		},
+		"supervisor.readProcessStderrLog": func(ctx context.Context, params []interface{}) (interface{}, error) {
+			return s.xmlrpcReadLog(params, true)
+		},
+		"supervisor.tailProcessStdoutLog": func(ctx context.Context, params []interface{}) (interface{}, error) {
+			return s.xmlrpcTailLog(params, false)
+		},
+		"supervisor.tailProcessStderrLog": func(ctx context.Context, params []interface{}) (interface{}, error) {
+			return s.xmlrpcTailLog(params, true)
+		},
+	}
+	m["system.listMethods"] = func(ctx context.Context, params []interface{}) (interface{}, error) {
+		names := make([]string, 0, len(m))
+		for k := range m {
+			names = append(names, k)
+		}
+		sort.Strings(names)
+		r := make([]interface{}, 0, len(names))
+		for _, v := range names {
+			r = append(r, v)
+		}
+		return r, nil
+	}
+	return m
+}
+
+func (s *serverInstance) xmlrpcProcessInfo(v *pb.Process) map[string]interface{} {
+	s.lock.RLock()
+	p := s.process[v.Spec.ProcessName]
+	s.lock.RUnlock()
+	p.lock.RLock()
+	stop := int64(0)
+	if !p.stoppedAt.IsZero() {
+		stop = p.stoppedAt.Unix()
+	}
+	exitStatus := p.lastExitCode
+	p.lock.RUnlock()
+
+	start := int64(v.Status.LastStartedTime)
+	description := v.Status.ProcessDesc
+	if (v.Status.Status == pb.ProcessStatus_RUNNING || v.Status.Status == pb.ProcessStatus_UNHEALTHY) && start > 0 {
+		description = fmt.Sprintf("pid %d, uptime %s", v.Status.Pid, time.Since(time.Unix(start, 0)).Truncate(time.Second))
+	}
+	spawnErr := ""
+	if v.Status.Status == pb.ProcessStatus_BACKOFF || v.Status.Status == pb.ProcessStatus_FATAL {
+		spawnErr = v.Status.ProcessDesc
+	}
+	return map[string]interface{}{
+		"name":           v.Spec.ProcessName,
+		"group":          v.Spec.ProcessName,
+		"description":    description,
+		"start":          start,
+		"stop":           stop,
+		"now":            time.Now().Unix(),
+		"state":          supervisorStateCodes[v.Status.Status],
+		"statename":      supervisorStateName(v.Status.Status),
+		"spawnerr":       spawnErr,
+		"exitstatus":     exitStatus,
+		"logfile":        "",
+		"stdout_logfile": "",
+		"stderr_logfile": "",
+		"pid":            v.Status.Pid,
+	}
+}
+
+func supervisorStateName(status pb.ProcessStatus_Status) string {
+	if status == pb.ProcessStatus_INIT {
+		return pb.ProcessStatus_STOPPED.String()
+	}
+	return status.String()
+}
+
+// xmlrpcProcess finds the process named by the first param, "group:name" is accepted as supervisorctl sends it
+func (s *serverInstance) xmlrpcProcess(params []interface{}) (*processInstances, error) {
+	if len(params) < 1 {
+		return nil, newFault(faultIncorrectParam, "INCORRECT_PARAMETERS")
+	}
+	name, ok := params[0].(string)
+	if !ok {
+		return nil, newFault(faultIncorrectParam, "INCORRECT_PARAMETERS")
+	}
+	if i := strings.LastIndexByte(name, ':'); i >= 0 {
+		name = name[i+1:]
+	}
+	s.lock.RLock()
+	p, ok := s.process[name]
+	s.lock.RUnlock()
+	if !ok {
+		return nil, newFault(faultBadName, "BAD_NAME: %v", name)
+	}
+	return p, nil
+}
+
+func (s *serverInstance) xmlrpcStartProcess(ctx context.Context, params []interface{}) (interface{}, error) {
+	p, err := s.xmlrpcProcess(params)
+	if err != nil {
+		return nil, err
+	}
+	wait := true
+	if len(params) > 1 {
+		wait, _ = params[1].(bool)
+	}
+	switch p.readStatus().Status.Status {
+	case pb.ProcessStatus_STARTING, pb.ProcessStatus_RUNNING, pb.ProcessStatus_UNHEALTHY, pb.ProcessStatus_STOPPING:
+		return nil, newFault(faultAlreadyStarted, "ALREADY_STARTED: %v", p.spec.ProcessName)
+	}
+	_, err = s.Command(ctx, &pb.CommandRequest{Command: pb.CommandRequest_START, ProcessName: p.spec.ProcessName})
+	if err != nil {
+		return nil, newFault(faultSpawnError, "SPAWN_ERROR: %v", err)
+	}
+	for wait {
+		st := p.readStatus().Status
+		switch st.Status {
+		case pb.ProcessStatus_RUNNING:
+			return true, nil
+		case pb.ProcessStatus_STARTING:
+		default:
+			return nil, newFault(faultSpawnError, "SPAWN_ERROR: %v %v", p.spec.ProcessName, st.ProcessDesc)
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(xmlrpcStartPollInterval):
+		}
+	}
+	return true, nil
+}
+
+func xmlrpcLogParams(params []interface{}) (offset, length int64, err error) {
+	if len(params) < 3 {
+		return 0, 0, newFault(faultIncorrectParam, "INCORRECT_PARAMETERS")
+	}
+	offset, ok1 := params[1].(int64)
+	length, ok2 := params[2].(int64)
+	if !ok1 || !ok2 {
+		return 0, 0, newFault(faultIncorrectParam, "INCORRECT_PARAMETERS")
+	}
+	return offset, length, nil
+}
+
+func (s *serverInstance) xmlrpcLogBuffer(params []interface{}, stderr bool) (*logBuffer, error) {
+	p, err := s.xmlrpcProcess(params)
+	if err != nil {
+		return nil, err
+	}
+	if stderr {
+		return p.stderrLog, nil
+	}
+	return p.stdoutLog, nil
+}
+
+func (s *serverInstance) xmlrpcReadLog(params []interface{}, stderr bool) (interface{}, error) {
+	buf, err := s.xmlrpcLogBuffer(params, stderr)
+	if err != nil {
+		return nil, err
+	}
+	offset, length, err := xmlrpcLogParams(params)
+	if err != nil {
+		return nil, err
+	}
+	data, err := buf.read(offset, length)
+	if err != nil {
+		return nil, newFault(faultBadArguments, "BAD_ARGUMENTS: %v", err)
+	}
+	return string(data), nil
+}
+
+// xmlrpcTailLog returns [bytes, offset, overflow] like supervisord tailProcessStdoutLog
+func (s *serverInstance) xmlrpcTailLog(params []interface{}, stderr bool) (interface{}, error) {
+	buf, err := s.xmlrpcLogBuffer(params, stderr)
+	if err != nil {
+		return nil, err
+	}
+	offset, length, err := xmlrpcLogParams(params)
+	if err != nil {
+		return nil, err
+	}
+	if length < 0 {
+		return nil, newFault(faultBadArguments, "BAD_ARGUMENTS: negative length")
+	}
+	size := buf.total()
+	if offset < 0 || offset > size {
+		offset = size
+	}
+	overflow := false
+	if size-offset > length {
+		overflow = true
+		offset = size - length
+	}
+	data, err := buf.read(offset, size-offset)
+	if err != nil {
+		return nil, newFault(faultBadArguments, "BAD_ARGUMENTS: %v", err)
+	}
+	return []interface{}{string(data), size, overflow}, nil
+}
+
+func (s *serverInstance) httpXMLRPC(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	// xmlrpc clients send text/xml, which a cross site form can not
+	ct, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if ct != "text/xml" && ct != "application/xml" && !httpNotSimple(r) {
+		http.Error(w, "xmlrpc needs text/xml content type", http.StatusForbidden)
+		return
+	}
+	w.Header().Set("Content-Type", "text/xml")
+	var call xmlrpcCall
+	err := xml.NewDecoder(r.Body).Decode(&call)
+	if err != nil {
+		w.Write(encodeXMLRPCResponse(nil, errors.Wrap(err, "parse xmlrpc request")))
+		return
+	}
+	method, ok := s.xmlrpcMethods()[call.MethodName]
+	if !ok {
+		w.Write(encodeXMLRPCResponse(nil, newFault(faultUnknownMethod, "UNKNOWN_METHOD: %v", call.MethodName)))
+		return
+	}
+	params := make([]interface{}, 0, len(call.Params))
+	for i := range call.Params {
+		params = append(params, call.Params[i].decode())
+	}
+	result, err := method(r.Context(), params)
+	w.Write(encodeXMLRPCResponse(result, err))
+}
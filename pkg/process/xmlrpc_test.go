@@ -0,0 +1,94 @@
+package process
+
+import (
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	pb "github.com/wangkechun/gosupervisor/pkg/proto"
+)
+
+type xmlrpcResponse struct {
+	Params []xmlrpcValue `xml:"params>param>value"`
+	Fault  *xmlrpcValue  `xml:"fault>value"`
+}
+
+func callXMLRPC(t *testing.T, url, body string) (interface{}, map[string]interface{}) {
+	resp, err := http.Post(url+"/RPC2", "text/xml", strings.NewReader(body))
+	assert.Nil(t, err)
+	defer resp.Body.Close()
+	var r xmlrpcResponse
+	assert.Nil(t, xml.NewDecoder(resp.Body).Decode(&r))
+	if r.Fault != nil {
+		return nil, r.Fault.decode().(map[string]interface{})
+	}
+	return r.Params[0].decode(), nil
+}
+
+func TestXMLRPC(t *testing.T) {
+	a := assert.New(t)
+	s := newServerInstance(&pb.ConfigFile{Version: ServiceVersion, Process: []*pb.ProcessSpec{
+		{ProcessName: "web", Command: "sleep 60"},
+	}})
+	a.Nil(s.initLoad())
+	s.process["web"].stdoutLog.Write([]byte("hello world\n"))
+	srv := httptest.NewServer(s.httpHandler())
+	defer srv.Close()
+
+	// a cross site form post is rejected
+	resp, err := http.Post(srv.URL+"/RPC2", "text/plain", strings.NewReader(`<methodCall><methodName>supervisor.startProcess</methodName></methodCall>`))
+	a.Nil(err)
+	resp.Body.Close()
+	a.Equal(http.StatusForbidden, resp.StatusCode)
+
+	r, fault := callXMLRPC(t, srv.URL, `<?xml version="1.0"?><methodCall><methodName>supervisor.getAllProcessInfo</methodName><params></params></methodCall>`)
+	a.Nil(fault)
+	infos := r.([]interface{})
+	a.Len(infos, 1)
+	info := infos[0].(map[string]interface{})
+	a.Equal("web", info["name"])
+	a.Equal("STOPPED", info["statename"])
+	a.Equal(int64(0), info["state"])
+
+	r, fault = callXMLRPC(t, srv.URL, `<methodCall><methodName>supervisor.readProcessStdoutLog</methodName><params>
+<param><value><string>web:web</string></value></param><param><value><int>6</int></value></param><param><value><int>5</int></value></param>
+</params></methodCall>`)
+	a.Nil(fault)
+	a.Equal("world", r)
+
+	r, fault = callXMLRPC(t, srv.URL, `<methodCall><methodName>supervisor.tailProcessStdoutLog</methodName><params>
+<param><value>web</value></param><param><value><i4>0</i4></value></param><param><value><i4>6</i4></value></param>
+</params></methodCall>`)
+	a.Nil(fault)
+	a.Equal([]interface{}{"world\n", int64(12), true}, r)
+
+	_, fault = callXMLRPC(t, srv.URL, `<methodCall><methodName>supervisor.stopProcess</methodName><params><param><value>none</value></param></params></methodCall>`)
+	a.Equal(int64(faultBadName), fault["faultCode"])
+	_, fault = callXMLRPC(t, srv.URL, `<methodCall><methodName>supervisor.stopProcess</methodName><params><param><value>web</value></param></params></methodCall>`)
+	a.Equal(int64(faultNotRunning), fault["faultCode"])
+	_, fault = callXMLRPC(t, srv.URL, `<methodCall><methodName>supervisor.unknown</methodName></methodCall>`)
+	a.Equal(int64(faultUnknownMethod), fault["faultCode"])
+}
+
+func TestLogBufferRead(t *testing.T) {
+	a := assert.New(t)
+	b := newLogBuffer(8)
+	b.Write([]byte("0123456789"))
+	data, err := b.read(0, 0)
+	a.Nil(err)
+	a.Equal("23456789", string(data))
+	data, err = b.read(-3, 0)
+	a.Nil(err)
+	a.Equal("789", string(data))
+	data, err = b.read(4, 2)
+	a.Nil(err)
+	a.Equal("45", string(data))
+	data, err = b.read(0, 1)
+	a.Nil(err)
+	a.Equal("", string(data))
+	_, err = b.read(11, 0)
+	a.NotNil(err)
+}
@@ -11,13 +11,30 @@ It has these top-level messages:
 	PingRequest
 	PingReply
 	ProcessSpec
+	LogRule
+	LogForward
+	ListenSocket
+	HealthCheck
+	Readiness
 	ProcessStatus
 	Process
 	ListRequest
 	ListReply
 	CommandRequest
 	CommandReply
+	WatchRequest
+	AttachRequest
+	AttachReply
+	ResizeRequest
+	ResizeReply
+	ExecRequest
+	ExecReply
+	ProcessEvent
+	Notification
+	NotificationPayload
 	ConfigFile
+	SavedState
+	SavedProcess
 */
 package pb
 
@@ -68,44 +85,174 @@ func (x ProcessSpec_Autorestart) String() string {
 }
 func (ProcessSpec_Autorestart) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{2, 0} }
 
+// what to do when the previous run is still running on schedule
+type ProcessSpec_Overlap int32
+
+const (
+	ProcessSpec_SKIP          ProcessSpec_Overlap = 0
+	ProcessSpec_QUEUE         ProcessSpec_Overlap = 1
+	ProcessSpec_KILL_PREVIOUS ProcessSpec_Overlap = 2
+)
+
+var ProcessSpec_Overlap_name = map[int32]string{
+	0: "SKIP",
+	1: "QUEUE",
+	2: "KILL_PREVIOUS",
+}
+var ProcessSpec_Overlap_value = map[string]int32{
+	"SKIP":          0,
+	"QUEUE":         1,
+	"KILL_PREVIOUS": 2,
+}
+
+func (x ProcessSpec_Overlap) String() string {
+	return proto.EnumName(ProcessSpec_Overlap_name, int32(x))
+}
+func (ProcessSpec_Overlap) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{2, 1} }
+
+type ProcessSpec_Type int32
+
+const (
+	ProcessSpec_SIMPLE ProcessSpec_Type = 0
+	// runs to completion, stays STARTING while running and becomes COMPLETED
+	// on an expected exit code, it is not restarted after that
+	ProcessSpec_ONESHOT ProcessSpec_Type = 1
+)
+
+var ProcessSpec_Type_name = map[int32]string{
+	0: "SIMPLE",
+	1: "ONESHOT",
+}
+var ProcessSpec_Type_value = map[string]int32{
+	"SIMPLE":  0,
+	"ONESHOT": 1,
+}
+
+func (x ProcessSpec_Type) String() string {
+	return proto.EnumName(ProcessSpec_Type_name, int32(x))
+}
+func (ProcessSpec_Type) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{2, 2} }
+
+type LogRule_Stream int32
+
+const (
+	LogRule_BOTH   LogRule_Stream = 0
+	LogRule_STDOUT LogRule_Stream = 1
+	LogRule_STDERR LogRule_Stream = 2
+)
+
+var LogRule_Stream_name = map[int32]string{
+	0: "BOTH",
+	1: "STDOUT",
+	2: "STDERR",
+}
+var LogRule_Stream_value = map[string]int32{
+	"BOTH":   0,
+	"STDOUT": 1,
+	"STDERR": 2,
+}
+
+func (x LogRule_Stream) String() string {
+	return proto.EnumName(LogRule_Stream_name, int32(x))
+}
+func (LogRule_Stream) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{3, 0} }
+
+type LogRule_Action int32
+
+const (
+	// only publish the event
+	LogRule_EVENT LogRule_Action = 0
+	// run exec with $GOSUPERVISOR_PROCESS_NAME, $GOSUPERVISOR_LOG_RULE and $GOSUPERVISOR_LOG_LINE
+	LogRule_EXEC LogRule_Action = 1
+	// post a NotificationPayload with trigger LOG_MATCH to url
+	LogRule_WEBHOOK LogRule_Action = 2
+	// stop and start the process
+	LogRule_RESTART LogRule_Action = 3
+)
+
+var LogRule_Action_name = map[int32]string{
+	0: "EVENT",
+	1: "EXEC",
+	2: "WEBHOOK",
+	3: "RESTART",
+}
+var LogRule_Action_value = map[string]int32{
+	"EVENT":   0,
+	"EXEC":    1,
+	"WEBHOOK": 2,
+	"RESTART": 3,
+}
+
+func (x LogRule_Action) String() string {
+	return proto.EnumName(LogRule_Action_name, int32(x))
+}
+func (LogRule_Action) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{3, 1} }
+
+type LogForward_Target int32
+
+const (
+	LogForward_SYSLOG   LogForward_Target = 0
+	LogForward_JOURNALD LogForward_Target = 1
+)
+
+var LogForward_Target_name = map[int32]string{
+	0: "SYSLOG",
+	1: "JOURNALD",
+}
+var LogForward_Target_value = map[string]int32{
+	"SYSLOG":   0,
+	"JOURNALD": 1,
+}
+
+func (x LogForward_Target) String() string {
+	return proto.EnumName(LogForward_Target_name, int32(x))
+}
+func (LogForward_Target) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{4, 0} }
+
 type ProcessStatus_Status int32
 
 const (
-	ProcessStatus_INIT     ProcessStatus_Status = 0
-	ProcessStatus_STARTING ProcessStatus_Status = 1
-	ProcessStatus_RUNNING  ProcessStatus_Status = 2
-	ProcessStatus_STOPPED  ProcessStatus_Status = 4
-	ProcessStatus_STOPPING ProcessStatus_Status = 6
-	ProcessStatus_BACKOFF  ProcessStatus_Status = 7
-	ProcessStatus_FATAL    ProcessStatus_Status = 8
-	ProcessStatus_EXITED   ProcessStatus_Status = 9
+	ProcessStatus_INIT      ProcessStatus_Status = 0
+	ProcessStatus_STARTING  ProcessStatus_Status = 1
+	ProcessStatus_RUNNING   ProcessStatus_Status = 2
+	ProcessStatus_STOPPED   ProcessStatus_Status = 4
+	ProcessStatus_STOPPING  ProcessStatus_Status = 6
+	ProcessStatus_BACKOFF   ProcessStatus_Status = 7
+	ProcessStatus_FATAL     ProcessStatus_Status = 8
+	ProcessStatus_EXITED    ProcessStatus_Status = 9
+	ProcessStatus_UNHEALTHY ProcessStatus_Status = 10
+	ProcessStatus_COMPLETED ProcessStatus_Status = 11
 )
 
 var ProcessStatus_Status_name = map[int32]string{
-	0: "INIT",
-	1: "STARTING",
-	2: "RUNNING",
-	4: "STOPPED",
-	6: "STOPPING",
-	7: "BACKOFF",
-	8: "FATAL",
-	9: "EXITED",
+	0:  "INIT",
+	1:  "STARTING",
+	2:  "RUNNING",
+	4:  "STOPPED",
+	6:  "STOPPING",
+	7:  "BACKOFF",
+	8:  "FATAL",
+	9:  "EXITED",
+	10: "UNHEALTHY",
+	11: "COMPLETED",
 }
 var ProcessStatus_Status_value = map[string]int32{
-	"INIT":     0,
-	"STARTING": 1,
-	"RUNNING":  2,
-	"STOPPED":  4,
-	"STOPPING": 6,
-	"BACKOFF":  7,
-	"FATAL":    8,
-	"EXITED":   9,
+	"INIT":      0,
+	"STARTING":  1,
+	"RUNNING":   2,
+	"STOPPED":   4,
+	"STOPPING":  6,
+	"BACKOFF":   7,
+	"FATAL":     8,
+	"EXITED":    9,
+	"UNHEALTHY": 10,
+	"COMPLETED": 11,
 }
 
 func (x ProcessStatus_Status) String() string {
 	return proto.EnumName(ProcessStatus_Status_name, int32(x))
 }
-func (ProcessStatus_Status) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{3, 0} }
+func (ProcessStatus_Status) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{8, 0} }
 
 type CommandRequest_Command int32
 
@@ -115,6 +262,10 @@ const (
 	CommandRequest_START   CommandRequest_Command = 2
 	CommandRequest_RESTART CommandRequest_Command = 3
 	CommandRequest_KILL    CommandRequest_Command = 4
+	// send reloadsignal, the status is not changed
+	CommandRequest_RELOAD CommandRequest_Command = 5
+	// send signal, the status is not changed
+	CommandRequest_SIGNAL CommandRequest_Command = 6
 )
 
 var CommandRequest_Command_name = map[int32]string{
@@ -123,6 +274,8 @@ var CommandRequest_Command_name = map[int32]string{
 	2: "START",
 	3: "RESTART",
 	4: "KILL",
+	5: "RELOAD",
+	6: "SIGNAL",
 }
 var CommandRequest_Command_value = map[string]int32{
 	"NONE":    0,
@@ -130,12 +283,119 @@ var CommandRequest_Command_value = map[string]int32{
 	"START":   2,
 	"RESTART": 3,
 	"KILL":    4,
+	"RELOAD":  5,
+	"SIGNAL":  6,
 }
 
 func (x CommandRequest_Command) String() string {
 	return proto.EnumName(CommandRequest_Command_name, int32(x))
 }
-func (CommandRequest_Command) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{7, 0} }
+func (CommandRequest_Command) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{12, 0} }
+
+type AttachReply_Stream int32
+
+const (
+	AttachReply_STDOUT AttachReply_Stream = 0
+	AttachReply_STDERR AttachReply_Stream = 1
+)
+
+var AttachReply_Stream_name = map[int32]string{
+	0: "STDOUT",
+	1: "STDERR",
+}
+var AttachReply_Stream_value = map[string]int32{
+	"STDOUT": 0,
+	"STDERR": 1,
+}
+
+func (x AttachReply_Stream) String() string {
+	return proto.EnumName(AttachReply_Stream_name, int32(x))
+}
+func (AttachReply_Stream) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{16, 0} }
+
+type Notification_Trigger int32
+
+const (
+	Notification_NONE Notification_Trigger = 0
+	// process entered FATAL
+	Notification_FATAL Notification_Trigger = 1
+	// process exited with a code not in exitcodes
+	Notification_UNEXPECTED_EXIT Notification_Trigger = 2
+	// process exited flapping_count times in flapping_window seconds
+	Notification_FLAPPING Notification_Trigger = 3
+	// a LogRule of the process matched an output line
+	Notification_LOG_MATCH Notification_Trigger = 4
+)
+
+var Notification_Trigger_name = map[int32]string{
+	0: "NONE",
+	1: "FATAL",
+	2: "UNEXPECTED_EXIT",
+	3: "FLAPPING",
+	4: "LOG_MATCH",
+}
+var Notification_Trigger_value = map[string]int32{
+	"NONE":            0,
+	"FATAL":           1,
+	"UNEXPECTED_EXIT": 2,
+	"FLAPPING":        3,
+	"LOG_MATCH":       4,
+}
+
+func (x Notification_Trigger) String() string {
+	return proto.EnumName(Notification_Trigger_name, int32(x))
+}
+func (Notification_Trigger) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{22, 0} }
+
+// format of daemon logs, JSON and LOGFMT carry process_name, pid, status
+// and exit_code as fields on every lifecycle event
+type ConfigFile_LogFormat int32
+
+const (
+	ConfigFile_TEXT   ConfigFile_LogFormat = 0
+	ConfigFile_JSON   ConfigFile_LogFormat = 1
+	ConfigFile_LOGFMT ConfigFile_LogFormat = 2
+)
+
+var ConfigFile_LogFormat_name = map[int32]string{
+	0: "TEXT",
+	1: "JSON",
+	2: "LOGFMT",
+}
+var ConfigFile_LogFormat_value = map[string]int32{
+	"TEXT":   0,
+	"JSON":   1,
+	"LOGFMT": 2,
+}
+
+func (x ConfigFile_LogFormat) String() string {
+	return proto.EnumName(ConfigFile_LogFormat_name, int32(x))
+}
+func (ConfigFile_LogFormat) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{24, 0} }
+
+type SavedProcess_Desired int32
+
+const (
+	SavedProcess_UNSET   SavedProcess_Desired = 0
+	SavedProcess_STARTED SavedProcess_Desired = 1
+	SavedProcess_STOPPED SavedProcess_Desired = 2
+)
+
+var SavedProcess_Desired_name = map[int32]string{
+	0: "UNSET",
+	1: "STARTED",
+	2: "STOPPED",
+}
+var SavedProcess_Desired_value = map[string]int32{
+	"UNSET":   0,
+	"STARTED": 1,
+	"STOPPED": 2,
+}
+
+func (x SavedProcess_Desired) String() string {
+	return proto.EnumName(SavedProcess_Desired_name, int32(x))
+}
+func (SavedProcess_Desired) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{26, 0} }
 
 type PingRequest struct {
 }
@@ -173,6 +433,43 @@ type ProcessSpec struct {
 	Exitcodes    []int32                 `protobuf:"varint,9,rep,packed,name=exitcodes" json:"exitcodes,omitempty"`
 	Autostart    bool                    `protobuf:"varint,10,opt,name=autostart" json:"autostart,omitempty"`
 	Desc         string                  `protobuf:"bytes,11,opt,name=desc" json:"desc,omitempty"`
+	// the process is a supervisord eventlistener when events is not empty,
+	// e.g. PROCESS_STATE, PROCESS_STATE_EXITED, TICK_60
+	Events []string `protobuf:"bytes,12,rep,name=events" json:"events,omitempty"`
+	// max events buffered for the eventlistener, default 10
+	BufferSize  int32        `protobuf:"varint,13,opt,name=buffer_size,json=bufferSize" json:"buffer_size,omitempty"`
+	Healthcheck *HealthCheck `protobuf:"bytes,14,opt,name=healthcheck" json:"healthcheck,omitempty"`
+	// promote STARTING to RUNNING once ready instead of after startsecs,
+	// startsecs is then the upper bound to become ready
+	Readiness *Readiness `protobuf:"bytes,15,opt,name=readiness" json:"readiness,omitempty"`
+	// provide $NOTIFY_SOCKET and handle sd_notify STATUS=, MAINPID= and WATCHDOG=1
+	Notify bool `protobuf:"varint,16,opt,name=notify" json:"notify,omitempty"`
+	// restart the RUNNING process when WATCHDOG=1 is not received within this many seconds,
+	// passed to the process as $WATCHDOG_USEC, requires notify
+	WatchdogSec float32 `protobuf:"fixed32,17,opt,name=watchdog_sec,json=watchdogSec" json:"watchdog_sec,omitempty"`
+	// sockets bound by the daemon and passed as fd 3 onwards with $LISTEN_FDS and $LISTEN_PID,
+	// they stay open across restarts so no connection is refused
+	Listen []*ListenSocket `protobuf:"bytes,18,rep,name=listen" json:"listen,omitempty"`
+	// signal sent by the RELOAD command, e.g. HUP or USR2, default HUP
+	Reloadsignal string `protobuf:"bytes,19,opt,name=reloadsignal" json:"reloadsignal,omitempty"`
+	// cron expression like "*/5 * * * *" or @hourly, the process is started on schedule
+	// instead of autostart and is not restarted when it exits
+	Schedule string              `protobuf:"bytes,20,opt,name=schedule" json:"schedule,omitempty"`
+	Overlap  ProcessSpec_Overlap `protobuf:"varint,21,opt,name=overlap,enum=ProcessSpec_Overlap" json:"overlap,omitempty"`
+	Type     ProcessSpec_Type    `protobuf:"varint,22,opt,name=type,enum=ProcessSpec_Type" json:"type,omitempty"`
+	// started only after these processes are RUNNING, or COMPLETED for oneshot ones
+	DependsOn []string `protobuf:"bytes,23,rep,name=depends_on,json=dependsOn" json:"depends_on,omitempty"`
+	// also send stdout and stderr lines to syslog or journald
+	LogForward *LogForward `protobuf:"bytes,24,opt,name=log_forward,json=logForward" json:"log_forward,omitempty"`
+	// regular expressions over stdout and stderr lines triggering actions
+	LogRules []*LogRule `protobuf:"bytes,25,rep,name=log_rules,json=logRules" json:"log_rules,omitempty"`
+	// keep a pipe to stdin open for Attach and supervisor.sendProcessStdin
+	Stdin bool `protobuf:"varint,26,opt,name=stdin" json:"stdin,omitempty"`
+	// run the process on a pseudo-terminal as its controlling terminal, stdout and
+	// stderr are both captured as stdout through the pty master, linux only
+	Pty bool `protobuf:"varint,27,opt,name=pty" json:"pty,omitempty"`
+	// allow the Exec rpc to run commands in the directory and environment of the process
+	AllowExec bool `protobuf:"varint,28,opt,name=allow_exec,json=allowExec" json:"allow_exec,omitempty"`
 }
 
 func (m *ProcessSpec) Reset()                    { *m = ProcessSpec{} }
@@ -257,233 +554,1310 @@ func (m *ProcessSpec) GetDesc() string {
 	return ""
 }
 
-type ProcessStatus struct {
-	RestartedCount  int32                `protobuf:"varint,1,opt,name=restarted_count,json=restartedCount" json:"restarted_count,omitempty"`
-	LastStartedTime int32                `protobuf:"varint,2,opt,name=last_started_time,json=lastStartedTime" json:"last_started_time,omitempty"`
-	Pid             int32                `protobuf:"varint,3,opt,name=pid" json:"pid,omitempty"`
-	MemoryUsage     int32                `protobuf:"varint,4,opt,name=memory_usage,json=memoryUsage" json:"memory_usage,omitempty"`
-	Status          ProcessStatus_Status `protobuf:"varint,5,opt,name=status,enum=ProcessStatus_Status" json:"status,omitempty"`
-	ProcessDesc     string               `protobuf:"bytes,6,opt,name=process_desc,json=processDesc" json:"process_desc,omitempty"`
+func (m *ProcessSpec) GetEvents() []string {
+	if m != nil {
+		return m.Events
+	}
+	return nil
 }
 
-func (m *ProcessStatus) Reset()                    { *m = ProcessStatus{} }
-func (m *ProcessStatus) String() string            { return proto.CompactTextString(m) }
-func (*ProcessStatus) ProtoMessage()               {}
-func (*ProcessStatus) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
-
-func (m *ProcessStatus) GetRestartedCount() int32 {
+func (m *ProcessSpec) GetBufferSize() int32 {
 	if m != nil {
-		return m.RestartedCount
+		return m.BufferSize
 	}
 	return 0
 }
 
-func (m *ProcessStatus) GetLastStartedTime() int32 {
+func (m *ProcessSpec) GetHealthcheck() *HealthCheck {
 	if m != nil {
-		return m.LastStartedTime
+		return m.Healthcheck
 	}
-	return 0
+	return nil
 }
 
-func (m *ProcessStatus) GetPid() int32 {
+func (m *ProcessSpec) GetReadiness() *Readiness {
 	if m != nil {
-		return m.Pid
+		return m.Readiness
 	}
-	return 0
+	return nil
 }
 
-func (m *ProcessStatus) GetMemoryUsage() int32 {
+func (m *ProcessSpec) GetNotify() bool {
 	if m != nil {
-		return m.MemoryUsage
+		return m.Notify
+	}
+	return false
+}
+
+func (m *ProcessSpec) GetWatchdogSec() float32 {
+	if m != nil {
+		return m.WatchdogSec
 	}
 	return 0
 }
 
-func (m *ProcessStatus) GetStatus() ProcessStatus_Status {
+func (m *ProcessSpec) GetListen() []*ListenSocket {
 	if m != nil {
-		return m.Status
+		return m.Listen
 	}
-	return ProcessStatus_INIT
+	return nil
 }
 
-func (m *ProcessStatus) GetProcessDesc() string {
+func (m *ProcessSpec) GetReloadsignal() string {
 	if m != nil {
-		return m.ProcessDesc
+		return m.Reloadsignal
 	}
 	return ""
 }
 
-type Process struct {
-	Spec   *ProcessSpec   `protobuf:"bytes,1,opt,name=spec" json:"spec,omitempty"`
-	Status *ProcessStatus `protobuf:"bytes,2,opt,name=status" json:"status,omitempty"`
+func (m *ProcessSpec) GetSchedule() string {
+	if m != nil {
+		return m.Schedule
+	}
+	return ""
 }
 
-func (m *Process) Reset()                    { *m = Process{} }
-func (m *Process) String() string            { return proto.CompactTextString(m) }
-func (*Process) ProtoMessage()               {}
-func (*Process) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
-
-func (m *Process) GetSpec() *ProcessSpec {
+func (m *ProcessSpec) GetOverlap() ProcessSpec_Overlap {
 	if m != nil {
-		return m.Spec
+		return m.Overlap
 	}
-	return nil
+	return ProcessSpec_SKIP
 }
 
-func (m *Process) GetStatus() *ProcessStatus {
+func (m *ProcessSpec) GetType() ProcessSpec_Type {
 	if m != nil {
-		return m.Status
+		return m.Type
 	}
-	return nil
+	return ProcessSpec_SIMPLE
 }
 
-type ListRequest struct {
+func (m *ProcessSpec) GetDependsOn() []string {
+	if m != nil {
+		return m.DependsOn
+	}
+	return nil
 }
 
-func (m *ListRequest) Reset()                    { *m = ListRequest{} }
-func (m *ListRequest) String() string            { return proto.CompactTextString(m) }
-func (*ListRequest) ProtoMessage()               {}
-func (*ListRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
-
-type ListReply struct {
-	Process []*Process `protobuf:"bytes,1,rep,name=process" json:"process,omitempty"`
+func (m *ProcessSpec) GetLogForward() *LogForward {
+	if m != nil {
+		return m.LogForward
+	}
+	return nil
 }
 
-func (m *ListReply) Reset()                    { *m = ListReply{} }
-func (m *ListReply) String() string            { return proto.CompactTextString(m) }
-func (*ListReply) ProtoMessage()               {}
-func (*ListReply) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
-
-func (m *ListReply) GetProcess() []*Process {
+func (m *ProcessSpec) GetLogRules() []*LogRule {
 	if m != nil {
-		return m.Process
+		return m.LogRules
 	}
 	return nil
 }
 
-type CommandRequest struct {
-	Command     CommandRequest_Command `protobuf:"varint,1,opt,name=command,enum=CommandRequest_Command" json:"command,omitempty"`
-	ProcessName string                 `protobuf:"bytes,2,opt,name=process_name,json=processName" json:"process_name,omitempty"`
+func (m *ProcessSpec) GetStdin() bool {
+	if m != nil {
+		return m.Stdin
+	}
+	return false
 }
 
-func (m *CommandRequest) Reset()                    { *m = CommandRequest{} }
-func (m *CommandRequest) String() string            { return proto.CompactTextString(m) }
-func (*CommandRequest) ProtoMessage()               {}
-func (*CommandRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
-
-func (m *CommandRequest) GetCommand() CommandRequest_Command {
+func (m *ProcessSpec) GetPty() bool {
 	if m != nil {
-		return m.Command
+		return m.Pty
 	}
-	return CommandRequest_NONE
+	return false
 }
 
-func (m *CommandRequest) GetProcessName() string {
+func (m *ProcessSpec) GetAllowExec() bool {
 	if m != nil {
-		return m.ProcessName
+		return m.AllowExec
 	}
-	return ""
+	return false
 }
 
-type CommandReply struct {
+// LogRule acts on output lines of a process matching pattern, every match is
+// published as a ProcessEvent with log_rule and log_line set
+type LogRule struct {
+	// RE2 regular expression like "OutOfMemoryError" or "^panic:"
+	Pattern string         `protobuf:"bytes,1,opt,name=pattern" json:"pattern,omitempty"`
+	Stream  LogRule_Stream `protobuf:"varint,2,opt,name=stream,enum=LogRule_Stream" json:"stream,omitempty"`
+	Action  LogRule_Action `protobuf:"varint,3,opt,name=action,enum=LogRule_Action" json:"action,omitempty"`
+	// shell command of EXEC, run in the process directory
+	Exec string   `protobuf:"bytes,4,opt,name=exec" json:"exec,omitempty"`
+	Url  []string `protobuf:"bytes,5,rep,name=url" json:"url,omitempty"`
+	// seconds, act at most once in this interval, default 60
+	RateLimit float32 `protobuf:"fixed32,6,opt,name=rate_limit,json=rateLimit" json:"rate_limit,omitempty"`
+	// name of the rule in events, without spaces, default rule1, rule2...
+	Name string `protobuf:"bytes,7,opt,name=name" json:"name,omitempty"`
 }
 
-func (m *CommandReply) Reset()                    { *m = CommandReply{} }
-func (m *CommandReply) String() string            { return proto.CompactTextString(m) }
-func (*CommandReply) ProtoMessage()               {}
-func (*CommandReply) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+func (m *LogRule) Reset()                    { *m = LogRule{} }
+func (m *LogRule) String() string            { return proto.CompactTextString(m) }
+func (*LogRule) ProtoMessage()               {}
+func (*LogRule) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
 
-type ConfigFile struct {
-	Version string         `protobuf:"bytes,1,opt,name=version" json:"version,omitempty"`
-	Process []*ProcessSpec `protobuf:"bytes,2,rep,name=process" json:"process,omitempty"`
-	RpcAddr string         `protobuf:"bytes,3,opt,name=rpc_addr,json=rpcAddr" json:"rpc_addr,omitempty"`
+func (m *LogRule) GetPattern() string {
+	if m != nil {
+		return m.Pattern
+	}
+	return ""
 }
 
-func (m *ConfigFile) Reset()                    { *m = ConfigFile{} }
-func (m *ConfigFile) String() string            { return proto.CompactTextString(m) }
-func (*ConfigFile) ProtoMessage()               {}
-func (*ConfigFile) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+func (m *LogRule) GetStream() LogRule_Stream {
+	if m != nil {
+		return m.Stream
+	}
+	return LogRule_BOTH
+}
 
-func (m *ConfigFile) GetVersion() string {
+func (m *LogRule) GetAction() LogRule_Action {
 	if m != nil {
-		return m.Version
+		return m.Action
+	}
+	return LogRule_EVENT
+}
+
+func (m *LogRule) GetExec() string {
+	if m != nil {
+		return m.Exec
 	}
 	return ""
 }
 
-func (m *ConfigFile) GetProcess() []*ProcessSpec {
+func (m *LogRule) GetUrl() []string {
 	if m != nil {
-		return m.Process
+		return m.Url
 	}
 	return nil
 }
 
-func (m *ConfigFile) GetRpcAddr() string {
+func (m *LogRule) GetRateLimit() float32 {
 	if m != nil {
-		return m.RpcAddr
+		return m.RateLimit
 	}
-	return ""
+	return 0
 }
 
-func init() {
-	proto.RegisterType((*PingRequest)(nil), "PingRequest")
-	proto.RegisterType((*PingReply)(nil), "PingReply")
-	proto.RegisterType((*ProcessSpec)(nil), "ProcessSpec")
-	proto.RegisterType((*ProcessStatus)(nil), "ProcessStatus")
-	proto.RegisterType((*Process)(nil), "Process")
-	proto.RegisterType((*ListRequest)(nil), "ListRequest")
-	proto.RegisterType((*ListReply)(nil), "ListReply")
-	proto.RegisterType((*CommandRequest)(nil), "CommandRequest")
-	proto.RegisterType((*CommandReply)(nil), "CommandReply")
-	proto.RegisterType((*ConfigFile)(nil), "ConfigFile")
-	proto.RegisterEnum("ProcessSpec_Autorestart", ProcessSpec_Autorestart_name, ProcessSpec_Autorestart_value)
-	proto.RegisterEnum("ProcessStatus_Status", ProcessStatus_Status_name, ProcessStatus_Status_value)
-	proto.RegisterEnum("CommandRequest_Command", CommandRequest_Command_name, CommandRequest_Command_value)
+func (m *LogRule) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
+// LogForward sends every output line of a process to syslog or journald
+type LogForward struct {
+	Target LogForward_Target `protobuf:"varint,1,opt,name=target,enum=LogForward_Target" json:"target,omitempty"`
+	// syslog: unix, udp or tcp, default unix
+	Network string `protobuf:"bytes,2,opt,name=network" json:"network,omitempty"`
+	// syslog: default /dev/log for unix, host:port for udp and tcp (RFC5424);
+	// journald: default /run/systemd/journal/socket
+	Address string `protobuf:"bytes,3,opt,name=address" json:"address,omitempty"`
+	// syslog facility like user, daemon or local0, default user
+	Facility string `protobuf:"bytes,4,opt,name=facility" json:"facility,omitempty"`
+	// priority like info, notice or err, default info for stdout and err for stderr
+	StdoutPriority string `protobuf:"bytes,5,opt,name=stdout_priority,json=stdoutPriority" json:"stdout_priority,omitempty"`
+	StderrPriority string `protobuf:"bytes,6,opt,name=stderr_priority,json=stderrPriority" json:"stderr_priority,omitempty"`
+	// syslog app name and journald SYSLOG_IDENTIFIER, default the process name
+	Tag string `protobuf:"bytes,7,opt,name=tag" json:"tag,omitempty"`
+}
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+func (m *LogForward) Reset()                    { *m = LogForward{} }
+func (m *LogForward) String() string            { return proto.CompactTextString(m) }
+func (*LogForward) ProtoMessage()               {}
+func (*LogForward) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
 
-// Client API for GoSupervisor service
+func (m *LogForward) GetTarget() LogForward_Target {
+	if m != nil {
+		return m.Target
+	}
+	return LogForward_SYSLOG
+}
 
-type GoSupervisorClient interface {
-	Ping(ctx context.Context, in *PingRequest, opts ...grpc.CallOption) (*PingReply, error)
-	List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListReply, error)
-	Command(ctx context.Context, in *CommandRequest, opts ...grpc.CallOption) (*CommandReply, error)
+func (m *LogForward) GetNetwork() string {
+	if m != nil {
+		return m.Network
+	}
+	return ""
 }
 
-type goSupervisorClient struct {
-	cc *grpc.ClientConn
+func (m *LogForward) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
 }
 
-func NewGoSupervisorClient(cc *grpc.ClientConn) GoSupervisorClient {
-	return &goSupervisorClient{cc}
+func (m *LogForward) GetFacility() string {
+	if m != nil {
+		return m.Facility
+	}
+	return ""
 }
 
-func (c *goSupervisorClient) Ping(ctx context.Context, in *PingRequest, opts ...grpc.CallOption) (*PingReply, error) {
-	out := new(PingReply)
-	err := grpc.Invoke(ctx, "/GoSupervisor/Ping", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *LogForward) GetStdoutPriority() string {
+	if m != nil {
+		return m.StdoutPriority
 	}
-	return out, nil
+	return ""
 }
 
-func (c *goSupervisorClient) List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListReply, error) {
-	out := new(ListReply)
-	err := grpc.Invoke(ctx, "/GoSupervisor/List", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *LogForward) GetStderrPriority() string {
+	if m != nil {
+		return m.StderrPriority
 	}
-	return out, nil
+	return ""
 }
 
-func (c *goSupervisorClient) Command(ctx context.Context, in *CommandRequest, opts ...grpc.CallOption) (*CommandReply, error) {
+func (m *LogForward) GetTag() string {
+	if m != nil {
+		return m.Tag
+	}
+	return ""
+}
+
+// ListenSocket is a listening socket owned by the daemon
+type ListenSocket struct {
+	// tcp, tcp4, tcp6 or unix, default tcp
+	Network string `protobuf:"bytes,1,opt,name=network" json:"network,omitempty"`
+	Address string `protobuf:"bytes,2,opt,name=address" json:"address,omitempty"`
+	// passed in $LISTEN_FDNAMES, default the address
+	Name string `protobuf:"bytes,3,opt,name=name" json:"name,omitempty"`
+}
+
+func (m *ListenSocket) Reset()                    { *m = ListenSocket{} }
+func (m *ListenSocket) String() string            { return proto.CompactTextString(m) }
+func (*ListenSocket) ProtoMessage()               {}
+func (*ListenSocket) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+
+func (m *ListenSocket) GetNetwork() string {
+	if m != nil {
+		return m.Network
+	}
+	return ""
+}
+
+func (m *ListenSocket) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *ListenSocket) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+// HealthCheck probes a RUNNING process with one of http_get, tcp_connect or exec
+type HealthCheck struct {
+	// GET the url, healthy on 2xx and 3xx
+	HttpGet string `protobuf:"bytes,1,opt,name=http_get,json=httpGet" json:"http_get,omitempty"`
+	// connect to host:port
+	TcpConnect string `protobuf:"bytes,2,opt,name=tcp_connect,json=tcpConnect" json:"tcp_connect,omitempty"`
+	// run the shell command in the process directory, healthy on exit code 0
+	Exec string `protobuf:"bytes,3,opt,name=exec" json:"exec,omitempty"`
+	// seconds, default 10
+	Interval float32 `protobuf:"fixed32,4,opt,name=interval" json:"interval,omitempty"`
+	// seconds, default 3
+	Timeout float32 `protobuf:"fixed32,5,opt,name=timeout" json:"timeout,omitempty"`
+	// consecutive failures before UNHEALTHY, default 3
+	FailureThreshold int32 `protobuf:"varint,6,opt,name=failure_threshold,json=failureThreshold" json:"failure_threshold,omitempty"`
+	// restart the process after this many consecutive failures, never restart if 0
+	RestartThreshold int32 `protobuf:"varint,7,opt,name=restart_threshold,json=restartThreshold" json:"restart_threshold,omitempty"`
+}
+
+func (m *HealthCheck) Reset()                    { *m = HealthCheck{} }
+func (m *HealthCheck) String() string            { return proto.CompactTextString(m) }
+func (*HealthCheck) ProtoMessage()               {}
+func (*HealthCheck) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+
+func (m *HealthCheck) GetHttpGet() string {
+	if m != nil {
+		return m.HttpGet
+	}
+	return ""
+}
+
+func (m *HealthCheck) GetTcpConnect() string {
+	if m != nil {
+		return m.TcpConnect
+	}
+	return ""
+}
+
+func (m *HealthCheck) GetExec() string {
+	if m != nil {
+		return m.Exec
+	}
+	return ""
+}
+
+func (m *HealthCheck) GetInterval() float32 {
+	if m != nil {
+		return m.Interval
+	}
+	return 0
+}
+
+func (m *HealthCheck) GetTimeout() float32 {
+	if m != nil {
+		return m.Timeout
+	}
+	return 0
+}
+
+func (m *HealthCheck) GetFailureThreshold() int32 {
+	if m != nil {
+		return m.FailureThreshold
+	}
+	return 0
+}
+
+func (m *HealthCheck) GetRestartThreshold() int32 {
+	if m != nil {
+		return m.RestartThreshold
+	}
+	return 0
+}
+
+// Readiness is satisfied by one of tcp_connect, file, http_get or notify
+type Readiness struct {
+	// something listens on host:port
+	TcpConnect string `protobuf:"bytes,1,opt,name=tcp_connect,json=tcpConnect" json:"tcp_connect,omitempty"`
+	// the file exists
+	File string `protobuf:"bytes,2,opt,name=file" json:"file,omitempty"`
+	// GET the url returns 2xx or 3xx
+	HttpGet string `protobuf:"bytes,3,opt,name=http_get,json=httpGet" json:"http_get,omitempty"`
+	// the process sends READY=1 to $NOTIFY_SOCKET like sd_notify, implies ProcessSpec.notify
+	Notify bool `protobuf:"varint,4,opt,name=notify" json:"notify,omitempty"`
+	// seconds between probes, default 0.5
+	Interval float32 `protobuf:"fixed32,5,opt,name=interval" json:"interval,omitempty"`
+}
+
+func (m *Readiness) Reset()                    { *m = Readiness{} }
+func (m *Readiness) String() string            { return proto.CompactTextString(m) }
+func (*Readiness) ProtoMessage()               {}
+func (*Readiness) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+
+func (m *Readiness) GetTcpConnect() string {
+	if m != nil {
+		return m.TcpConnect
+	}
+	return ""
+}
+
+func (m *Readiness) GetFile() string {
+	if m != nil {
+		return m.File
+	}
+	return ""
+}
+
+func (m *Readiness) GetHttpGet() string {
+	if m != nil {
+		return m.HttpGet
+	}
+	return ""
+}
+
+func (m *Readiness) GetNotify() bool {
+	if m != nil {
+		return m.Notify
+	}
+	return false
+}
+
+func (m *Readiness) GetInterval() float32 {
+	if m != nil {
+		return m.Interval
+	}
+	return 0
+}
+
+type ProcessStatus struct {
+	RestartedCount  int32 `protobuf:"varint,1,opt,name=restarted_count,json=restartedCount" json:"restarted_count,omitempty"`
+	LastStartedTime int32 `protobuf:"varint,2,opt,name=last_started_time,json=lastStartedTime" json:"last_started_time,omitempty"`
+	Pid             int32 `protobuf:"varint,3,opt,name=pid" json:"pid,omitempty"`
+	// resident memory in KB
+	MemoryUsage int32                `protobuf:"varint,4,opt,name=memory_usage,json=memoryUsage" json:"memory_usage,omitempty"`
+	Status      ProcessStatus_Status `protobuf:"varint,5,opt,name=status,enum=ProcessStatus_Status" json:"status,omitempty"`
+	ProcessDesc string               `protobuf:"bytes,6,opt,name=process_desc,json=processDesc" json:"process_desc,omitempty"`
+	CpuPercent  float32              `protobuf:"fixed32,7,opt,name=cpu_percent,json=cpuPercent" json:"cpu_percent,omitempty"`
+	// how the last run of a scheduled process ended, e.g. exit status 1
+	LastRunResult string `protobuf:"bytes,8,opt,name=last_run_result,json=lastRunResult" json:"last_run_result,omitempty"`
+	// unix time of the next run of a scheduled process
+	NextRunTime int32 `protobuf:"varint,9,opt,name=next_run_time,json=nextRunTime" json:"next_run_time,omitempty"`
+}
+
+func (m *ProcessStatus) Reset()                    { *m = ProcessStatus{} }
+func (m *ProcessStatus) String() string            { return proto.CompactTextString(m) }
+func (*ProcessStatus) ProtoMessage()               {}
+func (*ProcessStatus) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+
+func (m *ProcessStatus) GetRestartedCount() int32 {
+	if m != nil {
+		return m.RestartedCount
+	}
+	return 0
+}
+
+func (m *ProcessStatus) GetLastStartedTime() int32 {
+	if m != nil {
+		return m.LastStartedTime
+	}
+	return 0
+}
+
+func (m *ProcessStatus) GetPid() int32 {
+	if m != nil {
+		return m.Pid
+	}
+	return 0
+}
+
+func (m *ProcessStatus) GetMemoryUsage() int32 {
+	if m != nil {
+		return m.MemoryUsage
+	}
+	return 0
+}
+
+func (m *ProcessStatus) GetStatus() ProcessStatus_Status {
+	if m != nil {
+		return m.Status
+	}
+	return ProcessStatus_INIT
+}
+
+func (m *ProcessStatus) GetProcessDesc() string {
+	if m != nil {
+		return m.ProcessDesc
+	}
+	return ""
+}
+
+func (m *ProcessStatus) GetCpuPercent() float32 {
+	if m != nil {
+		return m.CpuPercent
+	}
+	return 0
+}
+
+func (m *ProcessStatus) GetLastRunResult() string {
+	if m != nil {
+		return m.LastRunResult
+	}
+	return ""
+}
+
+func (m *ProcessStatus) GetNextRunTime() int32 {
+	if m != nil {
+		return m.NextRunTime
+	}
+	return 0
+}
+
+type Process struct {
+	Spec   *ProcessSpec   `protobuf:"bytes,1,opt,name=spec" json:"spec,omitempty"`
+	Status *ProcessStatus `protobuf:"bytes,2,opt,name=status" json:"status,omitempty"`
+}
+
+func (m *Process) Reset()                    { *m = Process{} }
+func (m *Process) String() string            { return proto.CompactTextString(m) }
+func (*Process) ProtoMessage()               {}
+func (*Process) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+
+func (m *Process) GetSpec() *ProcessSpec {
+	if m != nil {
+		return m.Spec
+	}
+	return nil
+}
+
+func (m *Process) GetStatus() *ProcessStatus {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+type ListRequest struct {
+}
+
+func (m *ListRequest) Reset()                    { *m = ListRequest{} }
+func (m *ListRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListRequest) ProtoMessage()               {}
+func (*ListRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+
+type ListReply struct {
+	Process []*Process `protobuf:"bytes,1,rep,name=process" json:"process,omitempty"`
+}
+
+func (m *ListReply) Reset()                    { *m = ListReply{} }
+func (m *ListReply) String() string            { return proto.CompactTextString(m) }
+func (*ListReply) ProtoMessage()               {}
+func (*ListReply) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+
+func (m *ListReply) GetProcess() []*Process {
+	if m != nil {
+		return m.Process
+	}
+	return nil
+}
+
+type CommandRequest struct {
+	Command     CommandRequest_Command `protobuf:"varint,1,opt,name=command,enum=CommandRequest_Command" json:"command,omitempty"`
+	ProcessName string                 `protobuf:"bytes,2,opt,name=process_name,json=processName" json:"process_name,omitempty"`
+	// RESTART process_names one batch after another, waiting for each batch to be RUNNING
+	// and healthy, aborting on failure. The restart goes on if the client disconnects.
+	Rolling      bool     `protobuf:"varint,3,opt,name=rolling" json:"rolling,omitempty"`
+	ProcessNames []string `protobuf:"bytes,4,rep,name=process_names,json=processNames" json:"process_names,omitempty"`
+	// batch size of a rolling restart, default 1
+	MaxUnavailable int32 `protobuf:"varint,5,opt,name=max_unavailable,json=maxUnavailable" json:"max_unavailable,omitempty"`
+	// signal name or number for SIGNAL, e.g. TERM, SIGUSR1 or 15
+	Signal string `protobuf:"bytes,6,opt,name=signal" json:"signal,omitempty"`
+}
+
+func (m *CommandRequest) Reset()                    { *m = CommandRequest{} }
+func (m *CommandRequest) String() string            { return proto.CompactTextString(m) }
+func (*CommandRequest) ProtoMessage()               {}
+func (*CommandRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+
+func (m *CommandRequest) GetCommand() CommandRequest_Command {
+	if m != nil {
+		return m.Command
+	}
+	return CommandRequest_NONE
+}
+
+func (m *CommandRequest) GetProcessName() string {
+	if m != nil {
+		return m.ProcessName
+	}
+	return ""
+}
+
+func (m *CommandRequest) GetRolling() bool {
+	if m != nil {
+		return m.Rolling
+	}
+	return false
+}
+
+func (m *CommandRequest) GetProcessNames() []string {
+	if m != nil {
+		return m.ProcessNames
+	}
+	return nil
+}
+
+func (m *CommandRequest) GetMaxUnavailable() int32 {
+	if m != nil {
+		return m.MaxUnavailable
+	}
+	return 0
+}
+
+func (m *CommandRequest) GetSignal() string {
+	if m != nil {
+		return m.Signal
+	}
+	return ""
+}
+
+type CommandReply struct {
+}
+
+func (m *CommandReply) Reset()                    { *m = CommandReply{} }
+func (m *CommandReply) String() string            { return proto.CompactTextString(m) }
+func (*CommandReply) ProtoMessage()               {}
+func (*CommandReply) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+
+type WatchRequest struct {
+	// only watch these processes, watch all processes if empty
+	ProcessName []string `protobuf:"bytes,1,rep,name=process_name,json=processName" json:"process_name,omitempty"`
+}
+
+func (m *WatchRequest) Reset()                    { *m = WatchRequest{} }
+func (m *WatchRequest) String() string            { return proto.CompactTextString(m) }
+func (*WatchRequest) ProtoMessage()               {}
+func (*WatchRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+
+func (m *WatchRequest) GetProcessName() []string {
+	if m != nil {
+		return m.ProcessName
+	}
+	return nil
+}
+
+type AttachRequest struct {
+	// required in the first request
+	ProcessName string `protobuf:"bytes,1,opt,name=process_name,json=processName" json:"process_name,omitempty"`
+	// written to the stdin of the process, needs ProcessSpec.stdin
+	Input []byte `protobuf:"bytes,2,opt,name=input,proto3" json:"input,omitempty"`
+}
+
+func (m *AttachRequest) Reset()                    { *m = AttachRequest{} }
+func (m *AttachRequest) String() string            { return proto.CompactTextString(m) }
+func (*AttachRequest) ProtoMessage()               {}
+func (*AttachRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+
+func (m *AttachRequest) GetProcessName() string {
+	if m != nil {
+		return m.ProcessName
+	}
+	return ""
+}
+
+func (m *AttachRequest) GetInput() []byte {
+	if m != nil {
+		return m.Input
+	}
+	return nil
+}
+
+type AttachReply struct {
+	Stream AttachReply_Stream `protobuf:"varint,1,opt,name=stream,enum=AttachReply_Stream" json:"stream,omitempty"`
+	Data   []byte             `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (m *AttachReply) Reset()                    { *m = AttachReply{} }
+func (m *AttachReply) String() string            { return proto.CompactTextString(m) }
+func (*AttachReply) ProtoMessage()               {}
+func (*AttachReply) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
+
+func (m *AttachReply) GetStream() AttachReply_Stream {
+	if m != nil {
+		return m.Stream
+	}
+	return AttachReply_STDOUT
+}
+
+func (m *AttachReply) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+type ResizeRequest struct {
+	ProcessName string `protobuf:"bytes,1,opt,name=process_name,json=processName" json:"process_name,omitempty"`
+	Rows        uint32 `protobuf:"varint,2,opt,name=rows" json:"rows,omitempty"`
+	Cols        uint32 `protobuf:"varint,3,opt,name=cols" json:"cols,omitempty"`
+}
+
+func (m *ResizeRequest) Reset()                    { *m = ResizeRequest{} }
+func (m *ResizeRequest) String() string            { return proto.CompactTextString(m) }
+func (*ResizeRequest) ProtoMessage()               {}
+func (*ResizeRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+
+func (m *ResizeRequest) GetProcessName() string {
+	if m != nil {
+		return m.ProcessName
+	}
+	return ""
+}
+
+func (m *ResizeRequest) GetRows() uint32 {
+	if m != nil {
+		return m.Rows
+	}
+	return 0
+}
+
+func (m *ResizeRequest) GetCols() uint32 {
+	if m != nil {
+		return m.Cols
+	}
+	return 0
+}
+
+type ResizeReply struct {
+}
+
+func (m *ResizeReply) Reset()                    { *m = ResizeReply{} }
+func (m *ResizeReply) String() string            { return proto.CompactTextString(m) }
+func (*ResizeReply) ProtoMessage()               {}
+func (*ResizeReply) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
+
+type ExecRequest struct {
+	ProcessName string `protobuf:"bytes,1,opt,name=process_name,json=processName" json:"process_name,omitempty"`
+	// the command and its arguments, not parsed by a shell
+	Args []string `protobuf:"bytes,2,rep,name=args" json:"args,omitempty"`
+}
+
+func (m *ExecRequest) Reset()                    { *m = ExecRequest{} }
+func (m *ExecRequest) String() string            { return proto.CompactTextString(m) }
+func (*ExecRequest) ProtoMessage()               {}
+func (*ExecRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
+
+func (m *ExecRequest) GetProcessName() string {
+	if m != nil {
+		return m.ProcessName
+	}
+	return ""
+}
+
+func (m *ExecRequest) GetArgs() []string {
+	if m != nil {
+		return m.Args
+	}
+	return nil
+}
+
+type ExecReply struct {
+	Stream AttachReply_Stream `protobuf:"varint,1,opt,name=stream,enum=AttachReply_Stream" json:"stream,omitempty"`
+	Data   []byte             `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	// set in the last reply
+	Exited   bool  `protobuf:"varint,3,opt,name=exited" json:"exited,omitempty"`
+	ExitCode int32 `protobuf:"varint,4,opt,name=exit_code,json=exitCode" json:"exit_code,omitempty"`
+}
+
+func (m *ExecReply) Reset()                    { *m = ExecReply{} }
+func (m *ExecReply) String() string            { return proto.CompactTextString(m) }
+func (*ExecReply) ProtoMessage()               {}
+func (*ExecReply) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
+
+func (m *ExecReply) GetStream() AttachReply_Stream {
+	if m != nil {
+		return m.Stream
+	}
+	return AttachReply_STDOUT
+}
+
+func (m *ExecReply) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *ExecReply) GetExited() bool {
+	if m != nil {
+		return m.Exited
+	}
+	return false
+}
+
+func (m *ExecReply) GetExitCode() int32 {
+	if m != nil {
+		return m.ExitCode
+	}
+	return 0
+}
+
+type ProcessEvent struct {
+	ProcessName string               `protobuf:"bytes,1,opt,name=process_name,json=processName" json:"process_name,omitempty"`
+	FromStatus  ProcessStatus_Status `protobuf:"varint,2,opt,name=from_status,json=fromStatus,enum=ProcessStatus_Status" json:"from_status,omitempty"`
+	ToStatus    ProcessStatus_Status `protobuf:"varint,3,opt,name=to_status,json=toStatus,enum=ProcessStatus_Status" json:"to_status,omitempty"`
+	// unix timestamp in milliseconds
+	Timestamp   int64  `protobuf:"varint,4,opt,name=timestamp" json:"timestamp,omitempty"`
+	Pid         int32  `protobuf:"varint,5,opt,name=pid" json:"pid,omitempty"`
+	ExitCode    int32  `protobuf:"varint,6,opt,name=exit_code,json=exitCode" json:"exit_code,omitempty"`
+	ProcessDesc string `protobuf:"bytes,7,opt,name=process_desc,json=processDesc" json:"process_desc,omitempty"`
+	// set when a LogRule matched, from_status and to_status are the current status then
+	LogRule string `protobuf:"bytes,8,opt,name=log_rule,json=logRule" json:"log_rule,omitempty"`
+	LogLine string `protobuf:"bytes,9,opt,name=log_line,json=logLine" json:"log_line,omitempty"`
+}
+
+func (m *ProcessEvent) Reset()                    { *m = ProcessEvent{} }
+func (m *ProcessEvent) String() string            { return proto.CompactTextString(m) }
+func (*ProcessEvent) ProtoMessage()               {}
+func (*ProcessEvent) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
+
+func (m *ProcessEvent) GetProcessName() string {
+	if m != nil {
+		return m.ProcessName
+	}
+	return ""
+}
+
+func (m *ProcessEvent) GetFromStatus() ProcessStatus_Status {
+	if m != nil {
+		return m.FromStatus
+	}
+	return ProcessStatus_INIT
+}
+
+func (m *ProcessEvent) GetToStatus() ProcessStatus_Status {
+	if m != nil {
+		return m.ToStatus
+	}
+	return ProcessStatus_INIT
+}
+
+func (m *ProcessEvent) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+func (m *ProcessEvent) GetPid() int32 {
+	if m != nil {
+		return m.Pid
+	}
+	return 0
+}
+
+func (m *ProcessEvent) GetExitCode() int32 {
+	if m != nil {
+		return m.ExitCode
+	}
+	return 0
+}
+
+func (m *ProcessEvent) GetProcessDesc() string {
+	if m != nil {
+		return m.ProcessDesc
+	}
+	return ""
+}
+
+func (m *ProcessEvent) GetLogRule() string {
+	if m != nil {
+		return m.LogRule
+	}
+	return ""
+}
+
+func (m *ProcessEvent) GetLogLine() string {
+	if m != nil {
+		return m.LogLine
+	}
+	return ""
+}
+
+type Notification struct {
+	// notify on all triggers if empty
+	Triggers []Notification_Trigger `protobuf:"varint,1,rep,packed,name=triggers,enum=Notification_Trigger" json:"triggers,omitempty"`
+	Url      []string               `protobuf:"bytes,2,rep,name=url" json:"url,omitempty"`
+	// only notify about these processes, all processes if empty
+	ProcessName []string `protobuf:"bytes,3,rep,name=process_name,json=processName" json:"process_name,omitempty"`
+	// seconds, default 5
+	Timeout float32 `protobuf:"fixed32,4,opt,name=timeout" json:"timeout,omitempty"`
+	// retries after the first failed post, default 3
+	Retries int32 `protobuf:"varint,5,opt,name=retries" json:"retries,omitempty"`
+	// sign the body with HMAC-SHA256 in the X-Gosupervisor-Signature header when set
+	HmacSecret string `protobuf:"bytes,6,opt,name=hmac_secret,json=hmacSecret" json:"hmac_secret,omitempty"`
+	// default 5
+	FlappingCount int32 `protobuf:"varint,7,opt,name=flapping_count,json=flappingCount" json:"flapping_count,omitempty"`
+	// seconds, default 60
+	FlappingWindow float32 `protobuf:"fixed32,8,opt,name=flapping_window,json=flappingWindow" json:"flapping_window,omitempty"`
+}
+
+func (m *Notification) Reset()                    { *m = Notification{} }
+func (m *Notification) String() string            { return proto.CompactTextString(m) }
+func (*Notification) ProtoMessage()               {}
+func (*Notification) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
+
+func (m *Notification) GetTriggers() []Notification_Trigger {
+	if m != nil {
+		return m.Triggers
+	}
+	return nil
+}
+
+func (m *Notification) GetUrl() []string {
+	if m != nil {
+		return m.Url
+	}
+	return nil
+}
+
+func (m *Notification) GetProcessName() []string {
+	if m != nil {
+		return m.ProcessName
+	}
+	return nil
+}
+
+func (m *Notification) GetTimeout() float32 {
+	if m != nil {
+		return m.Timeout
+	}
+	return 0
+}
+
+func (m *Notification) GetRetries() int32 {
+	if m != nil {
+		return m.Retries
+	}
+	return 0
+}
+
+func (m *Notification) GetHmacSecret() string {
+	if m != nil {
+		return m.HmacSecret
+	}
+	return ""
+}
+
+func (m *Notification) GetFlappingCount() int32 {
+	if m != nil {
+		return m.FlappingCount
+	}
+	return 0
+}
+
+func (m *Notification) GetFlappingWindow() float32 {
+	if m != nil {
+		return m.FlappingWindow
+	}
+	return 0
+}
+
+type NotificationPayload struct {
+	Trigger  Notification_Trigger `protobuf:"varint,1,opt,name=trigger,enum=Notification_Trigger" json:"trigger,omitempty"`
+	Hostname string               `protobuf:"bytes,2,opt,name=hostname" json:"hostname,omitempty"`
+	Event    *ProcessEvent        `protobuf:"bytes,3,opt,name=event" json:"event,omitempty"`
+}
+
+func (m *NotificationPayload) Reset()                    { *m = NotificationPayload{} }
+func (m *NotificationPayload) String() string            { return proto.CompactTextString(m) }
+func (*NotificationPayload) ProtoMessage()               {}
+func (*NotificationPayload) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
+
+func (m *NotificationPayload) GetTrigger() Notification_Trigger {
+	if m != nil {
+		return m.Trigger
+	}
+	return Notification_NONE
+}
+
+func (m *NotificationPayload) GetHostname() string {
+	if m != nil {
+		return m.Hostname
+	}
+	return ""
+}
+
+func (m *NotificationPayload) GetEvent() *ProcessEvent {
+	if m != nil {
+		return m.Event
+	}
+	return nil
+}
+
+type ConfigFile struct {
+	Version       string          `protobuf:"bytes,1,opt,name=version" json:"version,omitempty"`
+	Process       []*ProcessSpec  `protobuf:"bytes,2,rep,name=process" json:"process,omitempty"`
+	RpcAddr       string          `protobuf:"bytes,3,opt,name=rpc_addr,json=rpcAddr" json:"rpc_addr,omitempty"`
+	Notifications []*Notification `protobuf:"bytes,4,rep,name=notifications" json:"notifications,omitempty"`
+	// serve the HTTP/JSON api on this addr when set
+	HttpAddr string `protobuf:"bytes,5,opt,name=http_addr,json=httpAddr" json:"http_addr,omitempty"`
+	// persist desired state, restart counts and last exit info of processes here,
+	// they are restored when the daemon restarts. Processes still running are
+	// adopted, on linux their output goes through named pipes in the directory
+	// state_file.output so they keep writing while no daemon runs
+	StateFile string `protobuf:"bytes,6,opt,name=state_file,json=stateFile" json:"state_file,omitempty"`
+	// act as init, e.g. as the entrypoint of a container: reap orphaned zombies,
+	// stop all processes on SIGTERM and SIGINT and forward other signals to them
+	Init bool `protobuf:"varint,7,opt,name=init" json:"init,omitempty"`
+	// with init, the daemon stops everything and exits with the exit code of
+	// this process once it exits
+	MainProcess string               `protobuf:"bytes,8,opt,name=main_process,json=mainProcess" json:"main_process,omitempty"`
+	LogFormat   ConfigFile_LogFormat `protobuf:"varint,9,opt,name=log_format,json=logFormat,enum=ConfigFile_LogFormat" json:"log_format,omitempty"`
+	// prefix every line the processes write to the daemon stdout and stderr
+	// with the time and the process name
+	PrefixOutput bool `protobuf:"varint,10,opt,name=prefix_output,json=prefixOutput" json:"prefix_output,omitempty"`
+}
+
+func (m *ConfigFile) Reset()                    { *m = ConfigFile{} }
+func (m *ConfigFile) String() string            { return proto.CompactTextString(m) }
+func (*ConfigFile) ProtoMessage()               {}
+func (*ConfigFile) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
+
+func (m *ConfigFile) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *ConfigFile) GetProcess() []*ProcessSpec {
+	if m != nil {
+		return m.Process
+	}
+	return nil
+}
+
+func (m *ConfigFile) GetRpcAddr() string {
+	if m != nil {
+		return m.RpcAddr
+	}
+	return ""
+}
+
+func (m *ConfigFile) GetNotifications() []*Notification {
+	if m != nil {
+		return m.Notifications
+	}
+	return nil
+}
+
+func (m *ConfigFile) GetHttpAddr() string {
+	if m != nil {
+		return m.HttpAddr
+	}
+	return ""
+}
+
+func (m *ConfigFile) GetStateFile() string {
+	if m != nil {
+		return m.StateFile
+	}
+	return ""
+}
+
+func (m *ConfigFile) GetInit() bool {
+	if m != nil {
+		return m.Init
+	}
+	return false
+}
+
+func (m *ConfigFile) GetMainProcess() string {
+	if m != nil {
+		return m.MainProcess
+	}
+	return ""
+}
+
+func (m *ConfigFile) GetLogFormat() ConfigFile_LogFormat {
+	if m != nil {
+		return m.LogFormat
+	}
+	return ConfigFile_TEXT
+}
+
+func (m *ConfigFile) GetPrefixOutput() bool {
+	if m != nil {
+		return m.PrefixOutput
+	}
+	return false
+}
+
+// SavedState is the content of ConfigFile.state_file
+type SavedState struct {
+	Process []*SavedProcess `protobuf:"bytes,1,rep,name=process" json:"process,omitempty"`
+}
+
+func (m *SavedState) Reset()                    { *m = SavedState{} }
+func (m *SavedState) String() string            { return proto.CompactTextString(m) }
+func (*SavedState) ProtoMessage()               {}
+func (*SavedState) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
+
+func (m *SavedState) GetProcess() []*SavedProcess {
+	if m != nil {
+		return m.Process
+	}
+	return nil
+}
+
+type SavedProcess struct {
+	ProcessName string `protobuf:"bytes,1,opt,name=process_name,json=processName" json:"process_name,omitempty"`
+	// set by manual start and stop, overrides autostart
+	Desired        SavedProcess_Desired `protobuf:"varint,2,opt,name=desired,enum=SavedProcess_Desired" json:"desired,omitempty"`
+	RestartedCount int32                `protobuf:"varint,3,opt,name=restarted_count,json=restartedCount" json:"restarted_count,omitempty"`
+	LastExitCode   int32                `protobuf:"varint,4,opt,name=last_exit_code,json=lastExitCode" json:"last_exit_code,omitempty"`
+	// unix time the process last stopped or exited
+	LastStoppedTime int64                `protobuf:"varint,5,opt,name=last_stopped_time,json=lastStoppedTime" json:"last_stopped_time,omitempty"`
+	LastRunResult   string               `protobuf:"bytes,6,opt,name=last_run_result,json=lastRunResult" json:"last_run_result,omitempty"`
+	Status          ProcessStatus_Status `protobuf:"varint,7,opt,name=status,enum=ProcessStatus_Status" json:"status,omitempty"`
+	ProcessDesc     string               `protobuf:"bytes,8,opt,name=process_desc,json=processDesc" json:"process_desc,omitempty"`
+	// the running process is adopted by the next daemon when pid still has the same
+	// start time (clock ticks after boot) and cmdline
+	Pid             int32    `protobuf:"varint,9,opt,name=pid" json:"pid,omitempty"`
+	StartTime       uint64   `protobuf:"varint,10,opt,name=start_time,json=startTime" json:"start_time,omitempty"`
+	Cmdline         []string `protobuf:"bytes,11,rep,name=cmdline" json:"cmdline,omitempty"`
+	LastStartedTime int32    `protobuf:"varint,12,opt,name=last_started_time,json=lastStartedTime" json:"last_started_time,omitempty"`
+}
+
+func (m *SavedProcess) Reset()                    { *m = SavedProcess{} }
+func (m *SavedProcess) String() string            { return proto.CompactTextString(m) }
+func (*SavedProcess) ProtoMessage()               {}
+func (*SavedProcess) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26} }
+
+func (m *SavedProcess) GetProcessName() string {
+	if m != nil {
+		return m.ProcessName
+	}
+	return ""
+}
+
+func (m *SavedProcess) GetDesired() SavedProcess_Desired {
+	if m != nil {
+		return m.Desired
+	}
+	return SavedProcess_UNSET
+}
+
+func (m *SavedProcess) GetRestartedCount() int32 {
+	if m != nil {
+		return m.RestartedCount
+	}
+	return 0
+}
+
+func (m *SavedProcess) GetLastExitCode() int32 {
+	if m != nil {
+		return m.LastExitCode
+	}
+	return 0
+}
+
+func (m *SavedProcess) GetLastStoppedTime() int64 {
+	if m != nil {
+		return m.LastStoppedTime
+	}
+	return 0
+}
+
+func (m *SavedProcess) GetLastRunResult() string {
+	if m != nil {
+		return m.LastRunResult
+	}
+	return ""
+}
+
+func (m *SavedProcess) GetStatus() ProcessStatus_Status {
+	if m != nil {
+		return m.Status
+	}
+	return ProcessStatus_INIT
+}
+
+func (m *SavedProcess) GetProcessDesc() string {
+	if m != nil {
+		return m.ProcessDesc
+	}
+	return ""
+}
+
+func (m *SavedProcess) GetPid() int32 {
+	if m != nil {
+		return m.Pid
+	}
+	return 0
+}
+
+func (m *SavedProcess) GetStartTime() uint64 {
+	if m != nil {
+		return m.StartTime
+	}
+	return 0
+}
+
+func (m *SavedProcess) GetCmdline() []string {
+	if m != nil {
+		return m.Cmdline
+	}
+	return nil
+}
+
+func (m *SavedProcess) GetLastStartedTime() int32 {
+	if m != nil {
+		return m.LastStartedTime
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*PingRequest)(nil), "PingRequest")
+	proto.RegisterType((*PingReply)(nil), "PingReply")
+	proto.RegisterType((*ProcessSpec)(nil), "ProcessSpec")
+	proto.RegisterType((*LogRule)(nil), "LogRule")
+	proto.RegisterType((*LogForward)(nil), "LogForward")
+	proto.RegisterType((*ListenSocket)(nil), "ListenSocket")
+	proto.RegisterType((*HealthCheck)(nil), "HealthCheck")
+	proto.RegisterType((*Readiness)(nil), "Readiness")
+	proto.RegisterType((*ProcessStatus)(nil), "ProcessStatus")
+	proto.RegisterType((*Process)(nil), "Process")
+	proto.RegisterType((*ListRequest)(nil), "ListRequest")
+	proto.RegisterType((*ListReply)(nil), "ListReply")
+	proto.RegisterType((*CommandRequest)(nil), "CommandRequest")
+	proto.RegisterType((*CommandReply)(nil), "CommandReply")
+	proto.RegisterType((*WatchRequest)(nil), "WatchRequest")
+	proto.RegisterType((*AttachRequest)(nil), "AttachRequest")
+	proto.RegisterType((*AttachReply)(nil), "AttachReply")
+	proto.RegisterType((*ResizeRequest)(nil), "ResizeRequest")
+	proto.RegisterType((*ResizeReply)(nil), "ResizeReply")
+	proto.RegisterType((*ExecRequest)(nil), "ExecRequest")
+	proto.RegisterType((*ExecReply)(nil), "ExecReply")
+	proto.RegisterType((*ProcessEvent)(nil), "ProcessEvent")
+	proto.RegisterType((*Notification)(nil), "Notification")
+	proto.RegisterType((*NotificationPayload)(nil), "NotificationPayload")
+	proto.RegisterType((*ConfigFile)(nil), "ConfigFile")
+	proto.RegisterType((*SavedState)(nil), "SavedState")
+	proto.RegisterType((*SavedProcess)(nil), "SavedProcess")
+	proto.RegisterEnum("ProcessSpec_Autorestart", ProcessSpec_Autorestart_name, ProcessSpec_Autorestart_value)
+	proto.RegisterEnum("ProcessSpec_Overlap", ProcessSpec_Overlap_name, ProcessSpec_Overlap_value)
+	proto.RegisterEnum("ProcessSpec_Type", ProcessSpec_Type_name, ProcessSpec_Type_value)
+	proto.RegisterEnum("LogRule_Stream", LogRule_Stream_name, LogRule_Stream_value)
+	proto.RegisterEnum("LogRule_Action", LogRule_Action_name, LogRule_Action_value)
+	proto.RegisterEnum("LogForward_Target", LogForward_Target_name, LogForward_Target_value)
+	proto.RegisterEnum("ProcessStatus_Status", ProcessStatus_Status_name, ProcessStatus_Status_value)
+	proto.RegisterEnum("CommandRequest_Command", CommandRequest_Command_name, CommandRequest_Command_value)
+	proto.RegisterEnum("AttachReply_Stream", AttachReply_Stream_name, AttachReply_Stream_value)
+	proto.RegisterEnum("Notification_Trigger", Notification_Trigger_name, Notification_Trigger_value)
+	proto.RegisterEnum("ConfigFile_LogFormat", ConfigFile_LogFormat_name, ConfigFile_LogFormat_value)
+	proto.RegisterEnum("SavedProcess_Desired", SavedProcess_Desired_name, SavedProcess_Desired_value)
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for GoSupervisor service
+
+type GoSupervisorClient interface {
+	Ping(ctx context.Context, in *PingRequest, opts ...grpc.CallOption) (*PingReply, error)
+	List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListReply, error)
+	Command(ctx context.Context, in *CommandRequest, opts ...grpc.CallOption) (*CommandReply, error)
+	Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (GoSupervisor_WatchClient, error)
+	// stream the output of a process and write input to its stdin, the first
+	// request names the process, closing the stream detaches
+	Attach(ctx context.Context, opts ...grpc.CallOption) (GoSupervisor_AttachClient, error)
+	// set the window size of the pseudo-terminal of a process started with pty
+	Resize(ctx context.Context, in *ResizeRequest, opts ...grpc.CallOption) (*ResizeReply, error)
+	// run a one-off command in the directory and environment of a process,
+	// streaming its output, the last reply carries the exit code
+	Exec(ctx context.Context, in *ExecRequest, opts ...grpc.CallOption) (GoSupervisor_ExecClient, error)
+}
+
+type goSupervisorClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewGoSupervisorClient(cc *grpc.ClientConn) GoSupervisorClient {
+	return &goSupervisorClient{cc}
+}
+
+func (c *goSupervisorClient) Ping(ctx context.Context, in *PingRequest, opts ...grpc.CallOption) (*PingReply, error) {
+	out := new(PingReply)
+	err := grpc.Invoke(ctx, "/GoSupervisor/Ping", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goSupervisorClient) List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListReply, error) {
+	out := new(ListReply)
+	err := grpc.Invoke(ctx, "/GoSupervisor/List", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goSupervisorClient) Command(ctx context.Context, in *CommandRequest, opts ...grpc.CallOption) (*CommandReply, error) {
 	out := new(CommandReply)
 	err := grpc.Invoke(ctx, "/GoSupervisor/Command", in, out, c.cc, opts...)
 	if err != nil {
@@ -492,12 +1866,125 @@ func (c *goSupervisorClient) Command(ctx context.Context, in *CommandRequest, op
 	return out, nil
 }
 
+func (c *goSupervisorClient) Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (GoSupervisor_WatchClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_GoSupervisor_serviceDesc.Streams[0], c.cc, "/GoSupervisor/Watch", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &goSupervisorWatchClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type GoSupervisor_WatchClient interface {
+	Recv() (*ProcessEvent, error)
+	grpc.ClientStream
+}
+
+type goSupervisorWatchClient struct {
+	grpc.ClientStream
+}
+
+func (x *goSupervisorWatchClient) Recv() (*ProcessEvent, error) {
+	m := new(ProcessEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *goSupervisorClient) Attach(ctx context.Context, opts ...grpc.CallOption) (GoSupervisor_AttachClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_GoSupervisor_serviceDesc.Streams[1], c.cc, "/GoSupervisor/Attach", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &goSupervisorAttachClient{stream}
+	return x, nil
+}
+
+type GoSupervisor_AttachClient interface {
+	Send(*AttachRequest) error
+	Recv() (*AttachReply, error)
+	grpc.ClientStream
+}
+
+type goSupervisorAttachClient struct {
+	grpc.ClientStream
+}
+
+func (x *goSupervisorAttachClient) Send(m *AttachRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *goSupervisorAttachClient) Recv() (*AttachReply, error) {
+	m := new(AttachReply)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *goSupervisorClient) Resize(ctx context.Context, in *ResizeRequest, opts ...grpc.CallOption) (*ResizeReply, error) {
+	out := new(ResizeReply)
+	err := grpc.Invoke(ctx, "/GoSupervisor/Resize", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goSupervisorClient) Exec(ctx context.Context, in *ExecRequest, opts ...grpc.CallOption) (GoSupervisor_ExecClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_GoSupervisor_serviceDesc.Streams[2], c.cc, "/GoSupervisor/Exec", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &goSupervisorExecClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type GoSupervisor_ExecClient interface {
+	Recv() (*ExecReply, error)
+	grpc.ClientStream
+}
+
+type goSupervisorExecClient struct {
+	grpc.ClientStream
+}
+
+func (x *goSupervisorExecClient) Recv() (*ExecReply, error) {
+	m := new(ExecReply)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // Server API for GoSupervisor service
 
 type GoSupervisorServer interface {
 	Ping(context.Context, *PingRequest) (*PingReply, error)
 	List(context.Context, *ListRequest) (*ListReply, error)
 	Command(context.Context, *CommandRequest) (*CommandReply, error)
+	Watch(*WatchRequest, GoSupervisor_WatchServer) error
+	// stream the output of a process and write input to its stdin, the first
+	// request names the process, closing the stream detaches
+	Attach(GoSupervisor_AttachServer) error
+	// set the window size of the pseudo-terminal of a process started with pty
+	Resize(context.Context, *ResizeRequest) (*ResizeReply, error)
+	// run a one-off command in the directory and environment of a process,
+	// streaming its output, the last reply carries the exit code
+	Exec(*ExecRequest, GoSupervisor_ExecServer) error
 }
 
 func RegisterGoSupervisorServer(s *grpc.Server, srv GoSupervisorServer) {
@@ -558,6 +2045,92 @@ func _GoSupervisor_Command_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _GoSupervisor_Watch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GoSupervisorServer).Watch(m, &goSupervisorWatchServer{stream})
+}
+
+type GoSupervisor_WatchServer interface {
+	Send(*ProcessEvent) error
+	grpc.ServerStream
+}
+
+type goSupervisorWatchServer struct {
+	grpc.ServerStream
+}
+
+func (x *goSupervisorWatchServer) Send(m *ProcessEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _GoSupervisor_Attach_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(GoSupervisorServer).Attach(&goSupervisorAttachServer{stream})
+}
+
+type GoSupervisor_AttachServer interface {
+	Send(*AttachReply) error
+	Recv() (*AttachRequest, error)
+	grpc.ServerStream
+}
+
+type goSupervisorAttachServer struct {
+	grpc.ServerStream
+}
+
+func (x *goSupervisorAttachServer) Send(m *AttachReply) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *goSupervisorAttachServer) Recv() (*AttachRequest, error) {
+	m := new(AttachRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _GoSupervisor_Resize_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResizeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoSupervisorServer).Resize(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/GoSupervisor/Resize",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoSupervisorServer).Resize(ctx, req.(*ResizeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoSupervisor_Exec_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExecRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GoSupervisorServer).Exec(m, &goSupervisorExecServer{stream})
+}
+
+type GoSupervisor_ExecServer interface {
+	Send(*ExecReply) error
+	grpc.ServerStream
+}
+
+type goSupervisorExecServer struct {
+	grpc.ServerStream
+}
+
+func (x *goSupervisorExecServer) Send(m *ExecReply) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 var _GoSupervisor_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "GoSupervisor",
 	HandlerType: (*GoSupervisorServer)(nil),
@@ -574,62 +2147,195 @@ var _GoSupervisor_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Command",
 			Handler:    _GoSupervisor_Command_Handler,
 		},
+		{
+			MethodName: "Resize",
+			Handler:    _GoSupervisor_Resize_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Watch",
+			Handler:       _GoSupervisor_Watch_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Attach",
+			Handler:       _GoSupervisor_Attach_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "Exec",
+			Handler:       _GoSupervisor_Exec_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "gosupervisor.proto",
 }
 
 func init() { proto.RegisterFile("gosupervisor.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 780 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x64, 0x54, 0x4d, 0x8f, 0xeb, 0x34,
-	0x14, 0x6d, 0xd2, 0xa4, 0x49, 0x6f, 0x32, 0x9d, 0x60, 0x09, 0x11, 0x46, 0x2c, 0x42, 0x16, 0x8f,
-	0x0a, 0x44, 0x10, 0x03, 0x2b, 0x16, 0xa0, 0xbe, 0x4e, 0xe6, 0xa9, 0x9a, 0x2a, 0xad, 0xdc, 0x14,
-	0x9e, 0xd8, 0x54, 0x79, 0x89, 0x19, 0x45, 0x6a, 0x3e, 0xb0, 0xd3, 0x11, 0x5d, 0xf3, 0x63, 0xd8,
-	0xf0, 0x87, 0xf8, 0x37, 0xc8, 0xce, 0xf7, 0xbc, 0x55, 0x7c, 0xcf, 0x39, 0xb1, 0x7d, 0xcf, 0xf5,
-	0xbd, 0x80, 0x9e, 0x0b, 0x76, 0x29, 0x09, 0x7d, 0x49, 0x59, 0x41, 0xbd, 0x92, 0x16, 0x55, 0xe1,
-	0xde, 0x80, 0xb1, 0x4f, 0xf3, 0x67, 0x4c, 0xfe, 0xbc, 0x10, 0x56, 0xb9, 0x3f, 0xc2, 0xbc, 0x0e,
-	0xcb, 0xf3, 0x15, 0x7d, 0x05, 0xb7, 0x8c, 0xab, 0x63, 0x72, 0x7a, 0x21, 0x94, 0xa5, 0x45, 0x6e,
-	0x4b, 0x8e, 0xb4, 0x9c, 0xe3, 0x45, 0x03, 0xff, 0x5a, 0xa3, 0xee, 0xbf, 0x53, 0x30, 0xf6, 0xb4,
-	0x88, 0x09, 0x63, 0x87, 0x92, 0xc4, 0xe8, 0x4b, 0x30, 0xcb, 0x3a, 0x3c, 0xe5, 0x51, 0x46, 0x9a,
-	0xbf, 0x8c, 0x06, 0x0b, 0xa2, 0x8c, 0x20, 0x1b, 0xb4, 0xb8, 0xc8, 0xb2, 0x28, 0x4f, 0x6c, 0x59,
-	0xb0, 0x6d, 0x88, 0x10, 0x28, 0x17, 0x46, 0xa8, 0x3d, 0x15, 0xb0, 0x58, 0xa3, 0x2f, 0x60, 0x9e,
-	0xa4, 0x94, 0xc4, 0x55, 0x41, 0xaf, 0xb6, 0x22, 0x88, 0x1e, 0x40, 0x0e, 0x18, 0x24, 0x7f, 0x49,
-	0x69, 0x91, 0x67, 0x24, 0xaf, 0x6c, 0xd5, 0x99, 0xf2, 0xd3, 0x06, 0x10, 0xff, 0x9f, 0x55, 0x11,
-	0xad, 0x18, 0x89, 0x99, 0x3d, 0x73, 0xa4, 0xa5, 0x8c, 0x7b, 0x00, 0xb9, 0x60, 0x8a, 0x80, 0x92,
-	0x8a, 0xa6, 0x84, 0xd9, 0x9a, 0x23, 0x2d, 0x55, 0x3c, 0xc2, 0xd0, 0x4f, 0x60, 0x44, 0x97, 0xaa,
-	0xa0, 0x44, 0xa0, 0xb6, 0xee, 0x48, 0xcb, 0xc5, 0xbd, 0xed, 0x0d, 0xb2, 0xf6, 0x56, 0x3d, 0x8f,
-	0x87, 0x62, 0x7e, 0x3a, 0xf9, 0x2b, 0xad, 0xe2, 0x22, 0x21, 0xcc, 0x9e, 0x3b, 0xd3, 0xa5, 0x8a,
-	0x7b, 0x80, 0xb3, 0x5c, 0x5c, 0xef, 0x0b, 0x8e, 0xb4, 0xd4, 0x71, 0x0f, 0x70, 0x37, 0x12, 0xc2,
-	0x62, 0xdb, 0xa8, 0xdd, 0xe0, 0x6b, 0xf7, 0x67, 0x30, 0x06, 0x67, 0x21, 0x80, 0xd9, 0x31, 0x78,
-	0x0a, 0x76, 0xbf, 0x59, 0x13, 0x34, 0x07, 0xf5, 0x71, 0xb5, 0x3d, 0xf8, 0x96, 0x84, 0x16, 0x00,
-	0xc7, 0xc0, 0x7f, 0xbf, 0xf7, 0xd7, 0xa1, 0xff, 0x60, 0xc9, 0x48, 0x07, 0x25, 0xc4, 0x47, 0xdf,
-	0x9a, 0xba, 0xff, 0xc9, 0x70, 0xd3, 0x5e, 0xbc, 0x8a, 0xaa, 0x0b, 0xe3, 0x95, 0x6e, 0x76, 0x23,
-	0xc9, 0x29, 0x2e, 0x2e, 0x79, 0x25, 0x6a, 0xa6, 0xe2, 0x45, 0x07, 0xaf, 0x39, 0x8a, 0xbe, 0x86,
-	0x4f, 0xce, 0x11, 0xab, 0x4e, 0xad, 0xb6, 0x4a, 0x33, 0x22, 0x0a, 0xa8, 0xe2, 0x5b, 0x4e, 0x1c,
-	0x6a, 0x3c, 0x4c, 0x33, 0x82, 0x2c, 0x98, 0x96, 0x69, 0x22, 0xea, 0xa8, 0x62, 0xbe, 0xe4, 0xef,
-	0x22, 0x23, 0x59, 0x41, 0xaf, 0xa7, 0x0b, 0x8b, 0x9e, 0x89, 0xa8, 0xa4, 0x8a, 0x8d, 0x1a, 0x3b,
-	0x72, 0x08, 0x7d, 0x0b, 0x33, 0x26, 0xee, 0x64, 0xab, 0xc2, 0xe2, 0x4f, 0xbd, 0xd1, 0x4d, 0xbd,
-	0xfa, 0x83, 0x1b, 0xd1, 0xf0, 0xa5, 0x09, 0x9b, 0x66, 0xa3, 0x97, 0xf6, 0xc0, 0xdd, 0x3a, 0xc3,
-	0xac, 0xc9, 0x52, 0x07, 0x65, 0x13, 0x6c, 0x42, 0x6b, 0x82, 0x4c, 0xd0, 0x0f, 0xe1, 0x0a, 0x87,
-	0x9b, 0xe0, 0x9d, 0x25, 0x21, 0x03, 0x34, 0x7c, 0x0c, 0x02, 0x1e, 0xc8, 0x3c, 0x38, 0x84, 0xbb,
-	0xfd, 0xde, 0x7f, 0xb0, 0x94, 0x5a, 0xb7, 0xdb, 0xef, 0x39, 0x35, 0xe3, 0xd4, 0xdb, 0xd5, 0xfa,
-	0x69, 0xf7, 0xf8, 0x68, 0x69, 0xb5, 0xd3, 0xe1, 0x6a, 0x6b, 0xe9, 0xbc, 0x00, 0xfe, 0xfb, 0x0d,
-	0x77, 0x79, 0xee, 0x1e, 0x40, 0x6b, 0x2e, 0x8c, 0x1c, 0x50, 0x58, 0x49, 0x62, 0xe1, 0xa4, 0x71,
-	0x6f, 0x0e, 0xdf, 0x0a, 0x16, 0x0c, 0x7a, 0xd3, 0x25, 0x2b, 0x0b, 0xcd, 0x62, 0x9c, 0x6c, 0x9b,
-	0x25, 0x6f, 0xd2, 0x6d, 0xca, 0xaa, 0xb6, 0x49, 0xbf, 0x83, 0x79, 0x1d, 0xf2, 0x26, 0x75, 0x41,
-	0x6b, 0xb2, 0xb5, 0x25, 0x67, 0xba, 0x34, 0xee, 0xf5, 0x76, 0x13, 0xdc, 0x12, 0xee, 0x3f, 0x12,
-	0x2c, 0xd6, 0x75, 0x7b, 0x35, 0x7b, 0xa0, 0xef, 0xfb, 0xfe, 0x93, 0x84, 0xd1, 0x9f, 0x79, 0x63,
-	0x45, 0x17, 0x76, 0x8d, 0xf9, 0xba, 0xab, 0xe5, 0x8f, 0xba, 0xda, 0xfd, 0x05, 0xb4, 0xe6, 0x37,
-	0x6e, 0x76, 0xb0, 0x0b, 0x7c, 0x6b, 0xc2, 0x57, 0xdc, 0x44, 0x4b, 0xe2, 0x9e, 0x09, 0xdb, 0x6b,
-	0x9b, 0xb1, 0x5f, 0x07, 0x53, 0xae, 0x78, 0xda, 0x6c, 0xb7, 0x96, 0xe2, 0x2e, 0xc0, 0xec, 0xae,
-	0x51, 0x9e, 0xaf, 0x6e, 0x0a, 0xb0, 0x2e, 0xf2, 0x3f, 0xd2, 0xe7, 0xc7, 0xf4, 0x2c, 0x86, 0xc6,
-	0x78, 0x10, 0xb5, 0x21, 0x7a, 0xd3, 0xbb, 0x20, 0x0b, 0x17, 0xc6, 0x76, 0xb7, 0x24, 0xfa, 0x1c,
-	0x74, 0x5a, 0xc6, 0xa7, 0x28, 0x49, 0xda, 0x01, 0xa3, 0xd1, 0x32, 0x5e, 0x25, 0x09, 0xbd, 0xff,
-	0x5b, 0x02, 0xf3, 0x5d, 0x71, 0xe8, 0x06, 0x24, 0x72, 0x41, 0xe1, 0xb3, 0x10, 0x99, 0xde, 0x60,
-	0x42, 0xde, 0x81, 0xd7, 0x0d, 0x48, 0x77, 0xc2, 0x35, 0xbc, 0x14, 0xc8, 0xf4, 0x06, 0x05, 0xba,
-	0x03, 0xaf, 0xab, 0x8f, 0x3b, 0x41, 0xdf, 0xf4, 0xa6, 0xdc, 0xbe, 0x32, 0xf9, 0xee, 0xc6, 0x1b,
-	0xa5, 0x3b, 0x79, 0xab, 0xfc, 0x2e, 0x97, 0x1f, 0x3e, 0xcc, 0xc4, 0x70, 0xfe, 0xe1, 0xff, 0x00,
-	0x00, 0x00, 0xff, 0xff, 0x63, 0xbd, 0x5f, 0xdd, 0xb2, 0x05, 0x00, 0x00,
+	// 2576 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x58, 0xcd, 0x92, 0xe3, 0xb6,
+	0x11, 0x1e, 0xea, 0x5f, 0xad, 0x9f, 0xe1, 0x62, 0xd7, 0x36, 0x3d, 0xb6, 0xcb, 0x0a, 0xed, 0xf5,
+	0x4e, 0xbc, 0x0e, 0xed, 0x1d, 0x3b, 0x49, 0x55, 0x0e, 0xa9, 0xd2, 0xce, 0x70, 0x7e, 0xbc, 0xb2,
+	0xa4, 0x40, 0x9a, 0xdd, 0xb5, 0x2f, 0x2c, 0x2e, 0x89, 0xd1, 0xb0, 0x4c, 0x91, 0x0c, 0x09, 0xcd,
+	0x8f, 0x6f, 0x39, 0xa5, 0x2a, 0x97, 0xe4, 0x9a, 0x77, 0xc8, 0x2d, 0x0f, 0x90, 0x43, 0x0e, 0x79,
+	0x92, 0x1c, 0xfd, 0x0a, 0xa9, 0x54, 0x03, 0x20, 0x45, 0xcd, 0xcc, 0xba, 0xec, 0x54, 0x4e, 0x42,
+	0x7f, 0xdd, 0x80, 0x80, 0x06, 0xf0, 0xf5, 0x07, 0x02, 0x59, 0xc4, 0xd9, 0x2a, 0x61, 0xe9, 0x45,
+	0x90, 0xc5, 0xa9, 0x95, 0xa4, 0x31, 0x8f, 0xcd, 0x1e, 0x74, 0xa6, 0x41, 0xb4, 0xa0, 0xec, 0xf7,
+	0x2b, 0x96, 0x71, 0xf3, 0x0b, 0x68, 0x4b, 0x33, 0x09, 0xaf, 0xc9, 0x23, 0xd8, 0xce, 0x30, 0xda,
+	0x63, 0xce, 0x05, 0x4b, 0xb3, 0x20, 0x8e, 0x0c, 0x6d, 0xa0, 0xed, 0xb6, 0x69, 0x5f, 0xc1, 0xcf,
+	0x25, 0x6a, 0xfe, 0xab, 0x05, 0x9d, 0x69, 0x1a, 0x7b, 0x2c, 0xcb, 0x66, 0x09, 0xf3, 0xc8, 0xcf,
+	0xa0, 0x9b, 0x48, 0xd3, 0x89, 0xdc, 0x25, 0x53, 0xbd, 0x3a, 0x0a, 0x1b, 0xbb, 0x4b, 0x46, 0x0c,
+	0x68, 0x7a, 0xf1, 0x72, 0xe9, 0x46, 0xbe, 0x51, 0x11, 0xde, 0xdc, 0x24, 0x04, 0x6a, 0xab, 0x8c,
+	0xa5, 0x46, 0x55, 0xc0, 0xa2, 0x4d, 0xde, 0x85, 0xb6, 0x1f, 0xa4, 0xcc, 0xe3, 0x71, 0x7a, 0x6d,
+	0xd4, 0x84, 0x63, 0x0d, 0x90, 0x01, 0x74, 0x58, 0x74, 0x11, 0xa4, 0x71, 0xb4, 0x64, 0x11, 0x37,
+	0xea, 0x83, 0x2a, 0xfe, 0x5b, 0x09, 0xc2, 0xfe, 0x19, 0x77, 0x53, 0x9e, 0x31, 0x2f, 0x33, 0x1a,
+	0x03, 0x6d, 0xb7, 0x42, 0xd7, 0x00, 0x31, 0xa1, 0x2b, 0x8c, 0x94, 0xf1, 0x34, 0x60, 0x99, 0xd1,
+	0x1c, 0x68, 0xbb, 0x75, 0xba, 0x81, 0x91, 0xdf, 0x40, 0xc7, 0x5d, 0xf1, 0x38, 0x65, 0x02, 0x35,
+	0x5a, 0x03, 0x6d, 0xb7, 0xbf, 0x67, 0x58, 0xa5, 0x55, 0x5b, 0xc3, 0xb5, 0x9f, 0x96, 0x83, 0xf1,
+	0xdf, 0xd9, 0x55, 0xc0, 0xbd, 0xd8, 0x67, 0x99, 0xd1, 0x1e, 0x54, 0x77, 0xeb, 0x74, 0x0d, 0xa0,
+	0x17, 0x83, 0xe5, 0xb8, 0x30, 0xd0, 0x76, 0x5b, 0x74, 0x0d, 0x60, 0x36, 0x7c, 0x96, 0x79, 0x46,
+	0x47, 0x66, 0x03, 0xdb, 0xe4, 0x4d, 0x68, 0xb0, 0x0b, 0x16, 0xf1, 0xcc, 0xe8, 0x8a, 0xa5, 0x2a,
+	0x8b, 0xbc, 0x0f, 0x9d, 0x57, 0xab, 0xb3, 0x33, 0x96, 0x3a, 0x59, 0xf0, 0x1d, 0x33, 0x7a, 0x62,
+	0x19, 0x20, 0xa1, 0x59, 0xf0, 0x1d, 0x23, 0x16, 0x74, 0xce, 0x99, 0x1b, 0xf2, 0x73, 0xef, 0x9c,
+	0x79, 0xdf, 0x1a, 0xfd, 0x81, 0xb6, 0xdb, 0xd9, 0xeb, 0x5a, 0xc7, 0x02, 0xdb, 0x47, 0x8c, 0x96,
+	0x03, 0xc8, 0x2e, 0xb4, 0x53, 0xe6, 0xfa, 0x41, 0xc4, 0xb2, 0xcc, 0xd8, 0x16, 0xd1, 0x60, 0xd1,
+	0x1c, 0xa1, 0x6b, 0x27, 0x4e, 0x29, 0x8a, 0x79, 0x70, 0x76, 0x6d, 0xe8, 0x62, 0x05, 0xca, 0xc2,
+	0x93, 0x70, 0xe9, 0x72, 0xef, 0xdc, 0x8f, 0x17, 0x4e, 0xc6, 0x3c, 0xe3, 0x9e, 0xc8, 0x7d, 0x27,
+	0xc7, 0x66, 0xcc, 0x23, 0x0f, 0xa1, 0x11, 0x06, 0x19, 0x67, 0x91, 0x41, 0x06, 0xd5, 0xdd, 0xce,
+	0x5e, 0xcf, 0x1a, 0x09, 0x73, 0x16, 0x7b, 0xdf, 0x32, 0x4e, 0x95, 0x13, 0x37, 0x29, 0x65, 0x61,
+	0xec, 0xfa, 0x59, 0xb0, 0x88, 0xdc, 0xd0, 0xb8, 0x2f, 0x12, 0xb2, 0x81, 0x91, 0x1d, 0x68, 0x65,
+	0xde, 0x39, 0xf3, 0x57, 0x21, 0x33, 0x1e, 0x08, 0x7f, 0x61, 0x13, 0x0b, 0x9a, 0xf1, 0x05, 0x4b,
+	0x43, 0x37, 0x31, 0xde, 0x10, 0x9b, 0xf7, 0x60, 0x63, 0xf3, 0x26, 0xd2, 0x47, 0xf3, 0x20, 0xf2,
+	0x10, 0x6a, 0xfc, 0x3a, 0x61, 0xc6, 0x9b, 0x22, 0xf8, 0xde, 0x46, 0xf0, 0xfc, 0x3a, 0x61, 0x54,
+	0xb8, 0xc9, 0x7b, 0x00, 0x3e, 0x4b, 0x58, 0xe4, 0x67, 0x4e, 0x1c, 0x19, 0x6f, 0x89, 0xfd, 0x68,
+	0x2b, 0x64, 0x12, 0x91, 0x4f, 0xa0, 0x13, 0xc6, 0x0b, 0xe7, 0x2c, 0x4e, 0x2f, 0xdd, 0xd4, 0x37,
+	0x0c, 0x91, 0xc3, 0x8e, 0x35, 0x8a, 0x17, 0x87, 0x12, 0xa2, 0x10, 0x16, 0x6d, 0xf2, 0x10, 0xda,
+	0x18, 0x9d, 0xae, 0x42, 0x96, 0x19, 0x6f, 0x8b, 0x6c, 0xb4, 0x30, 0x96, 0xae, 0x42, 0x46, 0x5b,
+	0xa1, 0x6c, 0x64, 0xe4, 0x01, 0xd4, 0x33, 0xee, 0x07, 0x91, 0xb1, 0x23, 0x72, 0x2d, 0x0d, 0xa2,
+	0x43, 0x35, 0xe1, 0xd7, 0xc6, 0x3b, 0x02, 0xc3, 0x26, 0xce, 0xcd, 0x0d, 0xc3, 0xf8, 0xd2, 0x61,
+	0x57, 0xcc, 0x33, 0xde, 0x55, 0x47, 0x0b, 0x11, 0xfb, 0x8a, 0x79, 0xe6, 0x6f, 0xa1, 0x53, 0x3a,
+	0xb2, 0x04, 0xa0, 0x71, 0x3a, 0x7e, 0x36, 0x9e, 0xbc, 0xd0, 0xb7, 0x48, 0x1b, 0xea, 0x87, 0xc3,
+	0xd1, 0xcc, 0xd6, 0x35, 0xd2, 0x07, 0x38, 0x1d, 0xdb, 0x2f, 0xa7, 0xf6, 0xfe, 0xdc, 0x3e, 0xd0,
+	0x2b, 0xa4, 0x05, 0xb5, 0x39, 0x3d, 0xb5, 0xf5, 0xaa, 0xf9, 0x04, 0x9a, 0x2a, 0x6b, 0x08, 0xce,
+	0x9e, 0x9d, 0x4c, 0x65, 0xcf, 0xdf, 0x9d, 0xda, 0xa7, 0xd8, 0xf3, 0x1e, 0xf4, 0x9e, 0x9d, 0x8c,
+	0x46, 0xce, 0x94, 0xda, 0xcf, 0x4f, 0x26, 0xa7, 0x33, 0xbd, 0x62, 0xbe, 0x0f, 0x35, 0xcc, 0x1d,
+	0xfe, 0xd7, 0xec, 0xe4, 0xab, 0xe9, 0xc8, 0xd6, 0xb7, 0x48, 0x07, 0x9a, 0x93, 0xb1, 0x3d, 0x3b,
+	0x9e, 0xcc, 0x75, 0xcd, 0xfc, 0x7b, 0x05, 0x9a, 0x6a, 0xc1, 0x48, 0x11, 0x89, 0xcb, 0x39, 0x4b,
+	0x73, 0xda, 0xc9, 0x4d, 0xf2, 0x08, 0x1a, 0x19, 0x4f, 0x99, 0xbb, 0x14, 0xdc, 0xd1, 0xdf, 0xdb,
+	0xce, 0x93, 0x64, 0xcd, 0x04, 0x4c, 0x95, 0x1b, 0x03, 0x5d, 0x8f, 0x23, 0x71, 0x55, 0x6f, 0x04,
+	0x0e, 0x05, 0x4c, 0x95, 0x1b, 0xaf, 0x99, 0x48, 0x92, 0xe4, 0x16, 0xd1, 0xc6, 0x84, 0xae, 0xd2,
+	0x50, 0xd1, 0x09, 0x36, 0x31, 0xa1, 0xa9, 0xcb, 0x99, 0x13, 0x06, 0xcb, 0x80, 0xe7, 0x3c, 0x82,
+	0xc8, 0x08, 0x01, 0x1c, 0x44, 0xd0, 0x5d, 0x53, 0x0e, 0x82, 0x6d, 0xf3, 0x63, 0x68, 0xc8, 0x39,
+	0x61, 0x8e, 0x9e, 0x4e, 0xe6, 0xc7, 0xfa, 0x96, 0x58, 0xfd, 0xfc, 0x60, 0x72, 0x3a, 0xd7, 0x35,
+	0xd5, 0xb6, 0x29, 0xd5, 0x2b, 0xe6, 0xaf, 0xa1, 0x21, 0xa7, 0x85, 0x59, 0xb4, 0x9f, 0xdb, 0xe3,
+	0xb9, 0xbe, 0x85, 0xdd, 0xec, 0x97, 0xf6, 0xbe, 0xae, 0x61, 0xa2, 0x5e, 0xd8, 0x4f, 0x8f, 0x27,
+	0x93, 0x67, 0x7a, 0x05, 0x0d, 0x6a, 0xcf, 0xe6, 0x43, 0x3a, 0xd7, 0xab, 0xe6, 0x5f, 0x2a, 0x00,
+	0xeb, 0x23, 0x45, 0x3e, 0x86, 0x06, 0x77, 0xd3, 0x05, 0xe3, 0x22, 0x6f, 0xfd, 0x3d, 0x52, 0x3a,
+	0x6f, 0xd6, 0x5c, 0x78, 0xa8, 0x8a, 0xc0, 0x24, 0x47, 0x8c, 0x5f, 0xc6, 0xe9, 0xb7, 0x39, 0x0f,
+	0x2b, 0x13, 0x3d, 0xae, 0xef, 0xa7, 0x78, 0xf5, 0x25, 0x15, 0xe7, 0x26, 0x5e, 0xb3, 0x33, 0xd7,
+	0x0b, 0xc2, 0x80, 0xe7, 0x64, 0x5c, 0xd8, 0xa2, 0x66, 0x70, 0x3f, 0x5e, 0x71, 0x27, 0x49, 0x83,
+	0x38, 0xc5, 0x90, 0xba, 0xaa, 0x19, 0x02, 0x9e, 0x2a, 0x54, 0x05, 0xb2, 0x34, 0x5d, 0x07, 0x36,
+	0x8a, 0x40, 0x96, 0xa6, 0x45, 0xa0, 0x0e, 0x55, 0xee, 0x2e, 0x54, 0x52, 0xb1, 0x69, 0x9a, 0xd0,
+	0x90, 0xab, 0x10, 0xd9, 0xfb, 0x7a, 0x36, 0x9a, 0x1c, 0xe9, 0x5b, 0xa4, 0x0b, 0xad, 0x2f, 0x27,
+	0xa7, 0x74, 0x3c, 0x1c, 0x1d, 0xe8, 0x9a, 0xf9, 0x1c, 0xba, 0x65, 0x1a, 0x29, 0xaf, 0x53, 0x7b,
+	0xed, 0x3a, 0x2b, 0x9b, 0xeb, 0xcc, 0xf7, 0xb3, 0x5a, 0xda, 0xcf, 0xef, 0x35, 0xe8, 0x94, 0xf8,
+	0x92, 0xbc, 0x0d, 0xad, 0x73, 0xce, 0x13, 0x27, 0xcf, 0x76, 0x9b, 0x36, 0xd1, 0x3e, 0x62, 0x1c,
+	0xe9, 0x98, 0x7b, 0x89, 0xe3, 0xc5, 0x51, 0xc4, 0x3c, 0xae, 0x06, 0x07, 0xee, 0x25, 0xfb, 0x12,
+	0x29, 0x0e, 0x5d, 0xb5, 0x74, 0xe8, 0x76, 0xa0, 0x15, 0x44, 0x9c, 0xa5, 0x17, 0x6e, 0x28, 0x72,
+	0x5b, 0xa1, 0x85, 0x8d, 0x33, 0xe5, 0xc1, 0x92, 0xc5, 0x2b, 0x2e, 0x72, 0x5a, 0xa1, 0xb9, 0x49,
+	0x1e, 0xc3, 0xbd, 0x33, 0x37, 0x08, 0x57, 0x29, 0x73, 0xf8, 0x79, 0xca, 0xb2, 0xf3, 0x38, 0xf4,
+	0x45, 0x3a, 0xeb, 0x54, 0x57, 0x8e, 0x79, 0x8e, 0x63, 0xb0, 0xba, 0xf3, 0xa5, 0x60, 0x59, 0xf3,
+	0x74, 0xe5, 0x28, 0x82, 0xcd, 0x3f, 0x6b, 0xd0, 0x2e, 0x18, 0xff, 0xe6, 0x92, 0xb4, 0xbb, 0x96,
+	0x74, 0x16, 0x84, 0x4c, 0x2d, 0x56, 0xb4, 0x37, 0x52, 0x54, 0xdd, 0x4c, 0xd1, 0xba, 0x6c, 0xd4,
+	0x36, 0xca, 0x46, 0x39, 0x0b, 0xf5, 0xcd, 0x2c, 0x98, 0xff, 0xae, 0x42, 0x2f, 0x27, 0x63, 0xee,
+	0xf2, 0x55, 0x86, 0x47, 0x49, 0xcd, 0x9b, 0xf9, 0x8e, 0x17, 0xaf, 0x22, 0x39, 0xb3, 0x3a, 0xed,
+	0x17, 0xf0, 0x3e, 0xa2, 0xe4, 0x63, 0xb8, 0x17, 0xba, 0x19, 0x77, 0xf2, 0x58, 0x4c, 0x9f, 0x98,
+	0x6a, 0x9d, 0x6e, 0xa3, 0x63, 0x26, 0xf1, 0x79, 0xb0, 0x64, 0x82, 0x4e, 0x03, 0x5f, 0x4c, 0xb8,
+	0x4e, 0xb1, 0x89, 0xb5, 0x6c, 0xc9, 0x96, 0x71, 0x7a, 0xed, 0xac, 0x32, 0x77, 0xc1, 0xc4, 0x94,
+	0xeb, 0xb4, 0x23, 0xb1, 0x53, 0x84, 0xc8, 0x2f, 0x90, 0x98, 0x70, 0x4e, 0x62, 0xd6, 0xfd, 0xbd,
+	0x37, 0xac, 0x8d, 0x99, 0x5a, 0xf2, 0x87, 0xaa, 0xa0, 0xb2, 0x4e, 0x12, 0x45, 0xbe, 0xb1, 0xa1,
+	0x93, 0x0e, 0xb0, 0xd6, 0xbf, 0x0f, 0x1d, 0x2f, 0x59, 0x39, 0x09, 0x4b, 0x3d, 0xd4, 0x36, 0x4d,
+	0x91, 0x0c, 0xf0, 0x92, 0xd5, 0x54, 0x22, 0xe4, 0x23, 0x10, 0x53, 0x77, 0xd2, 0x55, 0xe4, 0xa4,
+	0x2c, 0x5b, 0x85, 0x52, 0x9c, 0xb4, 0x69, 0x0f, 0x61, 0xba, 0x8a, 0xa8, 0x00, 0x89, 0x09, 0xbd,
+	0x88, 0x5d, 0xc9, 0x38, 0xb1, 0xee, 0xb6, 0x9c, 0x3e, 0x82, 0x74, 0x15, 0xe1, 0x9a, 0xcd, 0x3f,
+	0x69, 0xc8, 0x56, 0x62, 0x6a, 0x2d, 0xa8, 0x9d, 0x8c, 0x4f, 0xe6, 0xf2, 0x5e, 0x09, 0x9e, 0x39,
+	0x19, 0x1f, 0x49, 0x12, 0xa2, 0xa7, 0xe3, 0x31, 0x1a, 0x82, 0x84, 0x66, 0xf3, 0xc9, 0x74, 0x6a,
+	0x1f, 0xe8, 0x35, 0x19, 0x37, 0x99, 0x4e, 0xd1, 0xd5, 0x40, 0xd7, 0xd3, 0xe1, 0xfe, 0xb3, 0xc9,
+	0xe1, 0xa1, 0xde, 0x94, 0xe5, 0x64, 0x3e, 0x1c, 0xe9, 0x2d, 0xbc, 0xb1, 0xf6, 0xcb, 0x13, 0x2c,
+	0x25, 0x6d, 0xd2, 0x83, 0xf6, 0xe9, 0xf8, 0xd8, 0x1e, 0x8e, 0xe6, 0xc7, 0x5f, 0xeb, 0x80, 0xe6,
+	0xfe, 0x04, 0x8b, 0x02, 0x7a, 0x3b, 0xe6, 0x0c, 0x9a, 0x2a, 0x79, 0x64, 0x00, 0xb5, 0x2c, 0x61,
+	0x9e, 0xa1, 0x29, 0xc1, 0x52, 0xaa, 0xc5, 0x54, 0x78, 0xc8, 0x47, 0x45, 0xe2, 0x2b, 0x22, 0xa6,
+	0xbf, 0x99, 0xf8, 0x3c, 0xe3, 0x28, 0x77, 0x91, 0x16, 0x72, 0xb9, 0xfb, 0x29, 0xb4, 0xa5, 0x89,
+	0x72, 0xd7, 0x84, 0xa6, 0xca, 0xbc, 0xa1, 0xa9, 0xda, 0xab, 0x06, 0xa1, 0xb9, 0xc3, 0xfc, 0x47,
+	0x05, 0xfa, 0xfb, 0x52, 0xa8, 0xaa, 0x31, 0xc8, 0x93, 0xb5, 0x92, 0x95, 0x74, 0xfb, 0x96, 0xb5,
+	0x19, 0x51, 0x98, 0x79, 0xdc, 0x2d, 0x7d, 0x5c, 0xb9, 0x53, 0x1f, 0xa7, 0x71, 0x18, 0x06, 0xd1,
+	0x42, 0x1c, 0xc1, 0x16, 0xcd, 0x4d, 0xf2, 0x01, 0xf4, 0xca, 0x9d, 0x33, 0xa3, 0x26, 0x0a, 0x54,
+	0xb7, 0xd4, 0x5b, 0x5c, 0x89, 0xa5, 0x7b, 0xe5, 0xac, 0x22, 0xf7, 0xc2, 0x0d, 0x42, 0xf7, 0x55,
+	0xc8, 0xc4, 0x89, 0xac, 0xd3, 0xfe, 0xd2, 0xbd, 0x3a, 0x5d, 0xa3, 0x78, 0x03, 0x95, 0xa0, 0x92,
+	0x87, 0x4f, 0x59, 0xe6, 0x0b, 0x68, 0xaa, 0x69, 0xe3, 0x51, 0x18, 0x4f, 0xc6, 0xb6, 0xac, 0x45,
+	0xb8, 0xc5, 0xba, 0x86, 0x3b, 0x2a, 0x8b, 0xcf, 0x66, 0x25, 0xc2, 0x08, 0xac, 0xf9, 0x7a, 0x0d,
+	0x37, 0x9a, 0xda, 0xa3, 0xc9, 0xf0, 0x40, 0xaf, 0xcb, 0x72, 0x7f, 0x34, 0x1e, 0x8e, 0xf4, 0x86,
+	0xd9, 0x87, 0x6e, 0x91, 0x9e, 0x24, 0xbc, 0x36, 0x9f, 0x40, 0xf7, 0x05, 0xaa, 0xc1, 0x3c, 0x9d,
+	0xb7, 0xdf, 0x0e, 0xd5, 0x1b, 0xb9, 0x31, 0x8f, 0xa1, 0x37, 0xe4, 0xdc, 0xfd, 0xa1, 0x3e, 0xb7,
+	0xf2, 0xf9, 0x00, 0xea, 0x41, 0x94, 0xac, 0x24, 0x0d, 0x77, 0xa9, 0x34, 0xcc, 0x04, 0x3a, 0xf9,
+	0x48, 0x78, 0x02, 0x1e, 0x17, 0xba, 0x42, 0xee, 0xe4, 0x7d, 0xab, 0xe4, 0xbd, 0xa9, 0x2d, 0x50,
+	0x99, 0xbb, 0xdc, 0x55, 0x03, 0x8a, 0xb6, 0x39, 0x28, 0xaa, 0xfd, 0xba, 0xc6, 0x6f, 0x95, 0x6a,
+	0xbc, 0x66, 0x7e, 0x03, 0x3d, 0xca, 0x50, 0x9e, 0xff, 0x84, 0xb9, 0x13, 0xa8, 0xa5, 0xf1, 0xa5,
+	0x3c, 0xda, 0x3d, 0x2a, 0xda, 0x88, 0x79, 0x71, 0x28, 0x4b, 0x73, 0x8f, 0x8a, 0x36, 0x1e, 0xee,
+	0x7c, 0x6c, 0xcc, 0xec, 0x01, 0x74, 0x50, 0xe7, 0xfd, 0xb4, 0x3f, 0x72, 0xd3, 0x05, 0xfe, 0x11,
+	0xe6, 0x5c, 0xb4, 0xcd, 0x3f, 0x68, 0xd0, 0x96, 0xc3, 0xfc, 0x3f, 0x32, 0x24, 0xde, 0x2e, 0x57,
+	0x01, 0x67, 0xbe, 0x3a, 0xd6, 0xca, 0x22, 0xef, 0xc8, 0x37, 0x92, 0x83, 0x6f, 0x22, 0xc5, 0xac,
+	0x2d, 0x04, 0xf6, 0x63, 0x9f, 0x99, 0xff, 0xac, 0x40, 0x57, 0x5d, 0x45, 0x1b, 0x9f, 0x3a, 0x3f,
+	0x66, 0x2d, 0xbf, 0x82, 0xce, 0x59, 0x1a, 0x2f, 0x9d, 0x12, 0x2d, 0xbc, 0x96, 0x8f, 0x01, 0x23,
+	0x65, 0x9b, 0xec, 0x41, 0x9b, 0xc7, 0x79, 0xaf, 0xea, 0x0f, 0xf5, 0x6a, 0xf1, 0x58, 0xf5, 0x79,
+	0x17, 0xda, 0x48, 0xa9, 0x19, 0x77, 0x97, 0x89, 0x98, 0x7c, 0x95, 0xae, 0x81, 0xbc, 0x92, 0xd4,
+	0xd7, 0x95, 0x64, 0x63, 0xb1, 0x8d, 0xcd, 0xc5, 0xde, 0x2a, 0x0a, 0xcd, 0xdb, 0x45, 0xe1, 0x6d,
+	0x68, 0xe5, 0xef, 0x04, 0x45, 0xf6, 0x4d, 0xf5, 0x38, 0xc8, 0x5d, 0x61, 0x10, 0x49, 0x86, 0x97,
+	0xae, 0x51, 0x10, 0x31, 0xf3, 0xfb, 0x0a, 0x74, 0xc7, 0x58, 0x5f, 0x03, 0xcf, 0x15, 0x2a, 0xf3,
+	0x09, 0xb4, 0x78, 0x1a, 0x2c, 0x16, 0x2c, 0x95, 0x8c, 0x87, 0x2b, 0x2d, 0x07, 0x58, 0x73, 0xe9,
+	0xa5, 0x45, 0x58, 0xae, 0x89, 0x2b, 0x6b, 0x4d, 0x7c, 0x73, 0x2b, 0xaa, 0x83, 0xea, 0x1d, 0x5c,
+	0x96, 0xeb, 0x96, 0xda, 0xa6, 0x6e, 0x41, 0x96, 0x53, 0x8f, 0x6e, 0x99, 0x9e, 0xdc, 0xc4, 0xba,
+	0x77, 0xbe, 0x74, 0x3d, 0x7c, 0x34, 0xa6, 0x8c, 0x2b, 0x72, 0x02, 0x84, 0x66, 0x02, 0x21, 0x0f,
+	0xa1, 0x7f, 0x16, 0xba, 0x49, 0x12, 0x44, 0x0b, 0x55, 0xf3, 0xa5, 0x84, 0xe9, 0xe5, 0xa8, 0x2c,
+	0xf9, 0x8f, 0x60, 0xbb, 0x08, 0xbb, 0x0c, 0x22, 0x3f, 0xbe, 0x14, 0x19, 0xab, 0xd0, 0xa2, 0xf7,
+	0x0b, 0x81, 0x9a, 0x53, 0x68, 0xaa, 0xe5, 0x96, 0x08, 0xaf, 0x28, 0x5c, 0x1a, 0xb9, 0x0f, 0xdb,
+	0xeb, 0x77, 0x90, 0x83, 0x35, 0x4c, 0xaf, 0x60, 0xcd, 0x3b, 0x1c, 0x0d, 0x65, 0xcd, 0xab, 0x62,
+	0x01, 0x1b, 0x4d, 0x8e, 0x9c, 0xaf, 0x86, 0xf3, 0xfd, 0x63, 0xbd, 0x66, 0xfe, 0x51, 0x83, 0xfb,
+	0xe5, 0x74, 0x4e, 0xdd, 0x6b, 0x7c, 0xab, 0x92, 0x4f, 0xa1, 0xa9, 0xf2, 0xa9, 0x2e, 0xd1, 0x6b,
+	0xb2, 0x9e, 0x47, 0xa1, 0x1a, 0x3a, 0x8f, 0x33, 0x5e, 0x2a, 0x15, 0x85, 0x4d, 0x3e, 0x80, 0xba,
+	0x78, 0xfd, 0x8b, 0xa3, 0x8a, 0x8f, 0xe7, 0xf2, 0x3d, 0xa1, 0xd2, 0x67, 0xfe, 0xb5, 0x0a, 0xb0,
+	0x1f, 0x47, 0x67, 0xc1, 0xe2, 0x30, 0x90, 0x0f, 0xab, 0xcd, 0xef, 0x39, 0xb9, 0x49, 0x3e, 0x5a,
+	0x97, 0xc0, 0xca, 0xa0, 0x7a, 0xab, 0xd6, 0xe6, 0x4e, 0x3c, 0x65, 0x69, 0xe2, 0x39, 0x28, 0x94,
+	0x73, 0x49, 0x97, 0x26, 0xde, 0xd0, 0xf7, 0x53, 0xf2, 0x39, 0xf4, 0xa2, 0xd2, 0x6a, 0x64, 0x79,
+	0xc2, 0x89, 0x95, 0xd7, 0x48, 0x37, 0x63, 0xf0, 0x42, 0x08, 0x89, 0x28, 0x06, 0xac, 0xab, 0x25,
+	0x72, 0x9e, 0x88, 0x11, 0xdf, 0x03, 0xc0, 0xeb, 0xc8, 0x1c, 0xa1, 0x2c, 0xe5, 0x49, 0x68, 0x0b,
+	0x44, 0xac, 0x86, 0x40, 0x2d, 0x88, 0x02, 0xb9, 0xfd, 0x2d, 0x2a, 0xda, 0x42, 0xaa, 0xb9, 0x41,
+	0xe4, 0xe4, 0x8b, 0x91, 0x97, 0xa4, 0x83, 0x58, 0xae, 0x29, 0xbe, 0x00, 0x50, 0x2f, 0xf3, 0xa5,
+	0xcb, 0x8d, 0xb6, 0xda, 0x88, 0x75, 0x96, 0xd4, 0x9b, 0x69, 0xe9, 0x72, 0xda, 0x0e, 0xf3, 0xa6,
+	0x2c, 0xbe, 0xec, 0x2c, 0xb8, 0x72, 0xe2, 0x15, 0x4f, 0x56, 0xf9, 0x07, 0x9b, 0xae, 0x04, 0x27,
+	0x02, 0x33, 0x1f, 0x43, 0xbb, 0xe8, 0x2c, 0xde, 0xcb, 0xf6, 0x4b, 0xf5, 0x92, 0xfb, 0x72, 0x36,
+	0x19, 0xcb, 0x47, 0xdf, 0x68, 0x72, 0x74, 0xf8, 0xd5, 0x5c, 0xaf, 0x98, 0xbf, 0x04, 0x98, 0xb9,
+	0x17, 0xcc, 0x47, 0x2a, 0x61, 0xe4, 0xd1, 0x4d, 0x0d, 0xd2, 0xb3, 0x84, 0xf7, 0x96, 0x10, 0xf9,
+	0x4f, 0x15, 0xba, 0x65, 0xcf, 0x8f, 0xa1, 0xc4, 0x4f, 0xa1, 0xe9, 0xb3, 0x2c, 0x48, 0x99, 0x5f,
+	0xd0, 0x61, 0x79, 0x08, 0xeb, 0x40, 0x3a, 0x69, 0x1e, 0x75, 0x97, 0xb0, 0xae, 0xde, 0x29, 0xac,
+	0x3f, 0x84, 0xbe, 0x10, 0xa1, 0x37, 0x29, 0xbc, 0x8b, 0xa8, 0x9d, 0x33, 0xdb, 0x5a, 0x7e, 0xc7,
+	0x49, 0x92, 0xcb, 0xef, 0xba, 0xa0, 0x4b, 0x25, 0xbf, 0x05, 0x2e, 0xe4, 0xf7, 0x1d, 0xb2, 0xb6,
+	0x71, 0x97, 0xac, 0x5d, 0x2b, 0xee, 0xe6, 0xff, 0xa2, 0xb8, 0x5b, 0xb7, 0xc9, 0x55, 0xd1, 0x75,
+	0x7b, 0x4d, 0xd7, 0xf2, 0x00, 0xa6, 0x5c, 0x4e, 0x18, 0x77, 0xbc, 0xa6, 0x3e, 0x1f, 0xce, 0x03,
+	0xf5, 0x29, 0x73, 0xe9, 0x0b, 0xc6, 0xed, 0x08, 0xf2, 0xcb, 0xcd, 0xbb, 0xdf, 0x1b, 0xdd, 0x3b,
+	0xdf, 0x1b, 0xa6, 0x05, 0x4d, 0x95, 0x7f, 0x64, 0x9d, 0xd3, 0xf1, 0xcc, 0x9e, 0xcb, 0x8f, 0x23,
+	0x42, 0x5a, 0xd9, 0x07, 0x52, 0x7b, 0xe7, 0x72, 0xbb, 0xb2, 0xf7, 0xb7, 0x0a, 0x74, 0x8f, 0xe2,
+	0x59, 0xf1, 0x3d, 0x97, 0x98, 0x50, 0xc3, 0x4f, 0xb7, 0xa4, 0x6b, 0x95, 0x3e, 0xe8, 0xee, 0x80,
+	0x55, 0x7c, 0xcf, 0x35, 0xb7, 0x30, 0x06, 0xf5, 0x2e, 0xe9, 0x5a, 0x25, 0x15, 0xbc, 0x03, 0x56,
+	0x21, 0x82, 0xcd, 0x2d, 0xf2, 0x78, 0xad, 0xfc, 0xb6, 0x6f, 0x28, 0xd9, 0x9d, 0x9e, 0xb5, 0xa1,
+	0xdd, 0xb6, 0xc8, 0xcf, 0xa1, 0x2e, 0xd4, 0x1b, 0xe9, 0x59, 0x65, 0x15, 0xb7, 0xb3, 0xc9, 0x43,
+	0xe6, 0xd6, 0x67, 0x1a, 0xf9, 0x04, 0x1a, 0x52, 0x2b, 0x90, 0xbe, 0xb5, 0x21, 0xdf, 0x76, 0xba,
+	0x65, 0x11, 0x61, 0x6e, 0xed, 0x6a, 0x9f, 0x69, 0x64, 0x17, 0x1a, 0x52, 0xcb, 0x90, 0xbe, 0xb5,
+	0x21, 0x98, 0x76, 0xba, 0x56, 0x59, 0xe4, 0x6c, 0x91, 0x0f, 0xa1, 0x86, 0xfa, 0x84, 0x74, 0xad,
+	0x92, 0xda, 0xd9, 0x01, 0xab, 0x10, 0x2d, 0xf8, 0xef, 0x4f, 0x6b, 0xdf, 0x54, 0x92, 0x57, 0xaf,
+	0x1a, 0xe2, 0xa3, 0xf7, 0xe7, 0xff, 0x1d, 0x00, 0x66, 0x32, 0xf6, 0x40, 0x0a, 0x17, 0x00, 0x00,
 }